@@ -0,0 +1,130 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBuildTimeout is returned when a build exceeds its allotted time.
+var ErrBuildTimeout = errors.New("build timed out")
+
+// BuildWithTimeout runs b.Build in a goroutine and returns a timeout error if it takes longer than d.
+// A build returning an error value is reported through the error result.
+//
+// Build cannot be truly cancelled: on timeout the goroutine keeps running until Build returns,
+// and the builder must not be reused until then. Prefer context-aware building for cancellable work.
+func BuildWithTimeout(b Builder, d time.Duration) (any, error) {
+	if b == nil {
+		return nil, errors.New("builder cannot be nil")
+	}
+
+	done := make(chan any, 1)
+	go func() {
+		done <- b.Build()
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		if err, isError := result.(error); isError {
+			return nil, err
+		}
+		return result, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%w after %s", ErrBuildTimeout, d)
+	}
+}
+
+// BuildTx builds b transactionally with respect to its after-build hooks.
+// The returned rollback runs the undo hooks registered with AddUndoHook in reverse order, at most once,
+// skipping those whose after-build hook didn't complete, e.g. because the build failed validation.
+// When the build fails, rollback is invoked automatically before the error is returned.
+// Builders not exposing a BaseBuilder get a no-op rollback.
+func BuildTx(b Builder) (result any, rollback func(), err error) {
+	if b == nil {
+		return nil, func() {}, errors.New("builder cannot be nil")
+	}
+
+	var base *BaseBuilder
+	var undoHooks []undoHook
+	if accessor, ok := b.(BaseBuilderAccessor); ok && accessor.Base() != nil {
+		base = accessor.Base()
+		undoHooks = slices.Clone(base.undoHooks)
+		base.afterHooksRun = 0
+	}
+
+	result = b.Build()
+
+	var completed []undoHook
+	for _, undo := range undoHooks {
+		if base != nil && undo.afterHook < base.afterHooksRun {
+			completed = append(completed, undo)
+		}
+	}
+	var once sync.Once
+	rollback = func() {
+		once.Do(func() {
+			for _, undo := range slices.Backward(completed) {
+				undo.fn()
+			}
+		})
+	}
+
+	if buildErr, isError := result.(error); isError {
+		rollback()
+		return nil, rollback, buildErr
+	}
+	return result, rollback, nil
+}
+
+// ResolveBuildOrder returns an order in which entities can be built so that each comes after its dependencies.
+// deps maps each entity name to the names it depends on; names only appearing as dependencies are included too.
+// The order is deterministic. A dependency cycle returns an error wrapping ErrCyclicReference that lists the cycle.
+func ResolveBuildOrder(deps map[string][]string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	order := make([]string, 0, len(deps))
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, name)
+			cycle := append(slices.Clone(path[start:]), name)
+			return fmt.Errorf("%w: %s", ErrCyclicReference, strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range slices.Sorted(slices.Values(deps[name])) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(deps)) {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
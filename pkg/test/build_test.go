@@ -0,0 +1,349 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildWithTimeout(t *testing.T) {
+	builder := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+
+	result, err := BuildWithTimeout(builder, time.Second)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := result.(*TestUser); !ok {
+		t.Errorf("Expected *TestUser, got %T", result)
+	}
+
+	// Build errors are surfaced through the error result
+	if _, err = BuildWithTimeout(NewUserBuilder(), time.Second); !errors.Is(err, ErrNameRequired) {
+		t.Errorf("Expected build error, got %v", err)
+	}
+
+	if _, err = BuildWithTimeout(nil, time.Second); err == nil {
+		t.Error("Expected error for nil builder")
+	}
+}
+
+func TestBuildWithTimeout_Exceeded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	slow := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	slow.AddValidator("slow", func(Builder, map[string]any) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	result, err := BuildWithTimeout(slow, 20*time.Millisecond)
+	if !errors.Is(err, ErrBuildTimeout) {
+		t.Fatalf("Expected ErrBuildTimeout, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected nil result on timeout, got %v", result)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected timeout to return promptly, took %s", elapsed)
+	}
+}
+
+func TestUserBuilder_WithSimulatedLatency(t *testing.T) {
+	const latency = 50 * time.Millisecond
+
+	builder := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	builder.WithSimulatedLatency(latency)
+
+	start := time.Now()
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Fatal("Expected build to succeed")
+	}
+	if elapsed := time.Since(start); elapsed < latency {
+		t.Errorf("Expected build to take at least %s, took %s", latency, elapsed)
+	}
+
+	builder.WithSimulatedLatency(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	err, isError := builder.BuildContext(ctx).(error)
+	if !isError || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Minute {
+		t.Errorf("Expected cancellation to cut the sleep short, took %s", elapsed)
+	}
+}
+
+func TestBuildTx(t *testing.T) {
+	var calls []string
+	newBuilder := func() *UserBuilder {
+		builder := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+		builder.AddAfterBuildHook(func(any) error {
+			calls = append(calls, "create user")
+			return nil
+		})
+		builder.AddUndoHook(func() { calls = append(calls, "delete user") })
+		builder.AddAfterBuildHook(func(any) error {
+			calls = append(calls, "create profile")
+			return nil
+		})
+		builder.AddUndoHook(func() { calls = append(calls, "delete profile") })
+		return builder
+	}
+
+	result, rollback, err := BuildTx(newBuilder())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := result.(*TestUser); !ok {
+		t.Fatalf("Expected *TestUser, got %T", result)
+	}
+	rollback()
+	rollback()
+
+	expected := []string{"create user", "create profile", "delete profile", "delete user"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestBuildTx_RollbackOnError(t *testing.T) {
+	var calls []string
+	builder := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	builder.AddAfterBuildHook(func(any) error {
+		calls = append(calls, "create user")
+		return nil
+	})
+	builder.AddUndoHook(func() { calls = append(calls, "delete user") })
+	builder.AddAfterBuildHook(func(any) error {
+		return errors.New("profile service unavailable")
+	})
+
+	_, _, err := BuildTx(builder)
+	if err == nil || !strings.Contains(err.Error(), "profile service unavailable") {
+		t.Fatalf("Expected after-build hook error, got %v", err)
+	}
+
+	expected := []string{"create user", "delete user"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("Expected automatic rollback calls %v, got %v", expected, calls)
+	}
+}
+
+func TestBuildTx_NoRollbackOfHooksNotRun(t *testing.T) {
+	var calls []string
+	builder := NewUserBuilder().WithName("John Doe")
+	builder.AddAfterBuildHook(func(any) error {
+		calls = append(calls, "create user")
+		return nil
+	})
+	builder.AddUndoHook(func() { calls = append(calls, "delete user") })
+
+	_, rollback, err := BuildTx(builder)
+	if !errors.Is(err, ErrEmailRequired) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	rollback()
+	if len(calls) != 0 {
+		t.Errorf("Expected no hooks or undo hooks to run, got %v", calls)
+	}
+
+	// Only the undo hooks of completed after-build hooks run, in reverse order
+	builder = NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	builder.AddAfterBuildHook(func(any) error { return nil })
+	builder.AddUndoHook(func() { calls = append(calls, "undo first") })
+	builder.AddAfterBuildHook(func(any) error { return nil })
+	builder.AddUndoHook(func() { calls = append(calls, "undo second") })
+	builder.AddAfterBuildHook(func(any) error { return errors.New("failed") })
+	builder.AddUndoHook(func() { calls = append(calls, "undo third") })
+
+	if _, _, err = BuildTx(builder); err == nil {
+		t.Fatal("Expected after-build hook error")
+	}
+	expected := []string{"undo second", "undo first"}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("Expected rollback calls %v, got %v", expected, calls)
+	}
+}
+
+// pipelineBuilder is a custom builder running the BaseBuilder build pipeline through its exported helpers.
+type pipelineBuilder struct {
+	*BaseBuilder
+
+	name string
+}
+
+func (b *pipelineBuilder) Build() any {
+	defer b.EnterGate()()
+	if err := b.SimulateLatency(context.Background()); err != nil {
+		return err
+	}
+	if err := b.RunBeforeBuildHooks(context.Background()); err != nil {
+		return err
+	}
+	if b.IsValidationEnabled() {
+		if err := b.RunValidators(b); err != nil {
+			return err
+		}
+	}
+	result := b.name
+	if err := b.RunAfterBuildHooks(context.Background(), result); err != nil {
+		return err
+	}
+	b.RecordBuild()
+	return result
+}
+
+func (b *pipelineBuilder) Clone() Builder {
+	base, _ := b.BaseBuilder.Clone().(*BaseBuilder)
+	return &pipelineBuilder{BaseBuilder: base, name: b.name}
+}
+
+func TestBuildTx_CustomBuilder(t *testing.T) {
+	var calls []string
+	builder := &pipelineBuilder{BaseBuilder: NewBaseBuilder()}
+	builder.AddBeforeBuildHook(func() error {
+		calls = append(calls, "before")
+		return nil
+	})
+	builder.AddValidator("named", func(b Builder, _ map[string]any) error {
+		if b.(*pipelineBuilder).name == "" {
+			return errors.New("name is required")
+		}
+		return nil
+	})
+	builder.AddAfterBuildHook(func(any) error {
+		calls = append(calls, "create")
+		return nil
+	})
+	builder.AddUndoHook(func() { calls = append(calls, "delete") })
+	builder.AddAfterBuildHook(func(any) error { return errors.New("failed") })
+
+	if _, _, err := BuildTx(builder); err == nil || !strings.Contains(err.Error(), "name is required") {
+		t.Fatalf("Expected the validator error, got %v", err)
+	}
+	builder.name = "widget"
+	if _, _, err := BuildTx(builder); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Fatalf("Expected the after-build hook error, got %v", err)
+	}
+	expected := []string{"before", "before", "create", "delete"}
+	if !slices.Equal(calls, expected) || builder.BuildCount() != 0 {
+		t.Errorf("Expected calls %v and no recorded builds, got %v in %d builds", expected, calls, builder.BuildCount())
+	}
+}
+
+func TestUserBuilder_ContextAwareHooks(t *testing.T) {
+	var calls []string
+	builder := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	builder.AddBeforeBuildHook(func() error {
+		calls = append(calls, "before")
+		return nil
+	})
+	builder.AddBeforeBuildHookCtx(func(ctx context.Context) error {
+		calls = append(calls, "before ctx")
+		return ctx.Err()
+	})
+	builder.AddAfterBuildHookCtx(func(ctx context.Context, result any) error {
+		calls = append(calls, "after ctx")
+		return ctx.Err()
+	})
+
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Fatal("Expected build to succeed")
+	}
+	if expected := []string{"before", "before ctx", "after ctx"}; !slices.Equal(calls, expected) {
+		t.Errorf("Expected hook calls %v, got %v", expected, calls)
+	}
+
+	// Cancel the context from the first hook, once the build has started
+	calls = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builder.beforeBuildHooks[0] = func(context.Context) error {
+		calls = append(calls, "before")
+		cancel()
+		return nil
+	}
+
+	err, isError := builder.BuildContext(ctx).(error)
+	if !isError || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the cancelled before-hook to abort the build, got %v", err)
+	}
+	if expected := []string{"before", "before ctx"}; !slices.Equal(calls, expected) {
+		t.Errorf("Expected after-build hooks not to run, got %v", calls)
+	}
+}
+
+func TestResolveBuildOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		deps     map[string][]string
+		expected []string
+	}{
+		{
+			name:     "linear chain",
+			deps:     map[string][]string{"order": {"user"}, "user": {"tenant"}},
+			expected: []string{"tenant", "user", "order"},
+		},
+		{
+			name: "diamond",
+			deps: map[string][]string{
+				"invoice": {"order", "payment"},
+				"order":   {"user"},
+				"payment": {"user"},
+			},
+			expected: []string{"user", "order", "payment", "invoice"},
+		},
+		{
+			name:     "independent entities",
+			deps:     map[string][]string{"b": nil, "a": nil},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := ResolveBuildOrder(tt.deps)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !slices.Equal(order, tt.expected) {
+				t.Errorf("Expected order %v, got %v", tt.expected, order)
+			}
+		})
+	}
+}
+
+func TestResolveBuildOrder_Cycle(t *testing.T) {
+	_, err := ResolveBuildOrder(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+	if !errors.Is(err, ErrCyclicReference) {
+		t.Fatalf("Expected cyclic reference error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected error to list the cycle, got %v", err)
+	}
+}
+
+func TestBuildWithTimeout_CustomBuilderLatency(t *testing.T) {
+	gate := NewSerialGate()
+	builder := &pipelineBuilder{BaseBuilder: NewBaseBuilder(), name: "widget"}
+	builder.WithSerialGate(gate).WithSimulatedLatency(50 * time.Millisecond)
+
+	if _, err := BuildWithTimeout(builder, time.Millisecond); !errors.Is(err, ErrBuildTimeout) {
+		t.Errorf("Expected the simulated latency to exceed the timeout, got %v", err)
+	}
+	if result, err := BuildWithTimeout(builder, time.Second); err != nil || result != "widget" {
+		t.Errorf("Expected the build to wait for its turn in the gate and succeed, got %v (%v)", result, err)
+	}
+}
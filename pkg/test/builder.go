@@ -1,6 +1,60 @@
 package testkit
 
-import "maps"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ScenarioTagKey is the tag holding the scenario name set by WithScenario.
+	ScenarioTagKey = "scenario"
+	// ScenarioTimestampTagKey is the tag holding the Unix time WithScenario was called.
+	ScenarioTimestampTagKey = "scenario_ts"
+	// SuiteTagKey is the tag holding the suite name read from SuiteEnvVar.
+	SuiteTagKey = "suite"
+	// SuiteEnvVar is the environment variable WithScenario reads the suite name from.
+	SuiteEnvVar = "TESTKIT_SUITE"
+	// EnvTagKey is the tag holding the environment set by WithEnvironment.
+	EnvTagKey = "env"
+
+	// randomTagAlphabet is the set of characters random tag keys and values are drawn from.
+	randomTagAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// randomTagKeyLength and randomTagValueLength are the lengths of random tag keys and values.
+	randomTagKeyLength   = 6
+	randomTagValueLength = 8
+
+	// asyncValidationWorkers bounds how many validators ValidateAsync runs concurrently.
+	asyncValidationWorkers = 8
+)
+
+// environmentProfiles maps the environments accepted by WithEnvironment to their validation profile.
+// An empty profile selects the builder's built-in rules.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var environmentProfiles = map[string]string{
+	"ci":      "strict",
+	"staging": "",
+	"test":    "relaxed",
+}
+
+// ErrBuilderFrozen is recorded when a frozen builder is mutated.
+var ErrBuilderFrozen = errors.New("builder is frozen")
+
+// ErrValidationTimeout is returned when validators don't finish within the timeout set with WithValidationTimeout.
+var ErrValidationTimeout = errors.New("validation timed out")
+
+// ErrBuilderConsumed is recorded when a builder guarded with GuardAfterBuild is mutated after Build.
+var ErrBuilderConsumed = errors.New("builder was mutated after Build")
 
 // Builder defines the interface that all builders must implement.
 // This provides a common contract for all test builders in the library.
@@ -16,15 +70,117 @@ type Builder interface {
 	Clone() Builder
 }
 
+// ValidatorFunc is a custom validation rule run at build time.
+// It receives the builder being validated and the validation context set with SetValidationContext.
+type ValidatorFunc func(b Builder, ctx map[string]any) error
+
+// DefaultValidationGroup is the validator group run by Build and used by AddValidator.
+const DefaultValidationGroup = "default"
+
+// namedValidator pairs a validator with the name used in its error messages and its group.
+type namedValidator struct {
+	group string
+	name  string
+	fn    ValidatorFunc
+}
+
+// ErrorFormatter produces a validation error message for a field, the violated rule, and the offending value.
+type ErrorFormatter func(field, rule string, value any) string
+
+// undoHook reverts the effects of the after-build hook at index afterHook.
+type undoHook struct {
+	afterHook int
+	fn        func()
+}
+
+// tagExpiry records when a tag set with WithTagTTL expires, according to its clock.
+type tagExpiry struct {
+	at    time.Time
+	clock Clock
+}
+
+// expired reports whether the tag has expired.
+func (e tagExpiry) expired() bool {
+	return !e.clock.Now().Before(e.at)
+}
+
+// AuditEntry records a single builder mutation, for debugging complex fixture construction.
+type AuditEntry struct {
+	// Method is the name of the mutating method, e.g. "WithName"
+	Method string
+	// Key is the field, tag, or metadata key that was changed, or "user" for MutateUser
+	Key string
+	// Value is the value that was set, nil when it is only computed at build time
+	Value any
+	// Timestamp is when the mutation happened, according to the global clock, see SetGlobalClock
+	Timestamp time.Time
+}
+
 // BaseBuilder provides common functionality for all builders.
 // It implements the Builder interface and can be embedded in specific builders.
 type BaseBuilder struct {
 	// tags holds metadata tags for the builder
 	tags map[string]string
+	// tagExpiries holds expirations of tags set with WithTagTTL
+	tagExpiries map[string]tagExpiry
+	// tagOrder holds tag keys in insertion order, oldest first
+	tagOrder []string
+	// tagLimit bounds the number of tags, evicting the oldest; zero means unlimited
+	tagLimit int
 	// validationEnabled controls whether validation should be performed
 	validationEnabled bool
 	// errors holds any validation or configuration errors
 	errors []error
+	// warnings holds non-fatal issues that don't prevent building
+	warnings []error
+	// buildCount tracks how many times the builder was built
+	buildCount int
+	// resetCount tracks how many times the builder was reset
+	resetCount int
+	// frozen rejects further mutations once set
+	frozen bool
+	// frozenErrorRecorded ensures ErrBuilderFrozen is recorded only once
+	frozenErrorRecorded bool
+	// autoFreeze freezes the builder after each build
+	autoFreeze bool
+	// guardAfterBuild marks the builder consumed after each build
+	guardAfterBuild bool
+	// consumed rejects further mutations until Reset once a guarded builder is built
+	consumed bool
+	// consumedErrorRecorded ensures ErrBuilderConsumed is recorded only once per consumption
+	consumedErrorRecorded bool
+	// dirty is set by every accepted mutation, reset or restore, invalidating memoized builds
+	dirty bool
+	// errorFormatter customizes validation error messages when set
+	errorFormatter ErrorFormatter
+	// validators holds custom validation rules run at build time
+	validators []namedValidator
+	// validationContext holds external data passed to validators
+	validationContext map[string]any
+	// validationTimeout bounds how long validators may run, zero meaning no bound
+	validationTimeout time.Duration
+	// validationProfile names the registered rule set replacing the builder's built-in rules
+	validationProfile string
+	// errorHandler is invoked for each error added with AddError
+	errorHandler func(error)
+	// latency is the simulated delay applied at build time
+	latency time.Duration
+	// beforeBuildHooks run before the object is constructed
+	beforeBuildHooks []func(ctx context.Context) error
+	// afterBuildHooks run on the built object after a successful build
+	afterBuildHooks []func(ctx context.Context, result any) error
+	// undoHooks revert the effects of after-build hooks, run in reverse order by BuildTx rollbacks
+	undoHooks []undoHook
+	// afterHooksRun counts the after-build hooks completed by the last build
+	afterHooksRun int
+	// gate serializes builds across builders sharing it
+	gate *SerialGate
+	// linkedResets reset shared resources, such as sequences, whenever the builder is reset
+	linkedResets []func()
+	// auditEnabled records mutations in auditTrail when set
+	auditEnabled bool
+	// auditTrail holds the recorded mutations in order
+	auditTrail []AuditEntry
 }
 
 // NewBaseBuilder creates a new BaseBuilder instance with default settings.
@@ -33,40 +189,215 @@ func NewBaseBuilder() *BaseBuilder {
 		tags:              make(map[string]string),
 		validationEnabled: true,
 		errors:            make([]error, 0),
+		warnings:          make([]error, 0),
 	}
 }
 
+// Base implements BaseBuilderAccessor, giving access to the BaseBuilder however deeply it is embedded.
+func (b *BaseBuilder) Base() *BaseBuilder {
+	return b
+}
+
 // WithTag adds a metadata tag to the builder.
 // Tags can be used for identification, debugging, or conditional logic.
 func (b *BaseBuilder) WithTag(key, value string) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.tags == nil {
 		b.tags = make(map[string]string)
 	}
+	if _, exists := b.tags[key]; !exists {
+		b.tagOrder = append(b.tagOrder, key)
+	}
 	b.tags[key] = value
+	delete(b.tagExpiries, key)
+	b.evictTags()
+	b.recordAudit("WithTag", key, value)
+	return b
+}
+
+// WithTagLimit bounds the builder to n tags. Once more than n tags exist, the oldest inserted tags are evicted,
+// which keeps long-lived builders accumulating per-build tags from growing without bound.
+// Overwriting a tag keeps its original position. A non-positive n removes the limit.
+func (b *BaseBuilder) WithTagLimit(n int) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.tagLimit = max(n, 0)
+	b.evictTags()
+	return b
+}
+
+// TagInsertionOrder returns the tag keys in insertion order, oldest first.
+func (b *BaseBuilder) TagInsertionOrder() []string {
+	return slices.Clone(b.tagOrder)
+}
+
+// evictTags removes the oldest tags while the tag limit is exceeded.
+func (b *BaseBuilder) evictTags() {
+	if b.tagLimit == 0 {
+		return
+	}
+	for len(b.tagOrder) > b.tagLimit {
+		oldest := b.tagOrder[0]
+		b.tagOrder = b.tagOrder[1:]
+		delete(b.tags, oldest)
+		delete(b.tagExpiries, oldest)
+	}
+}
+
+// WithTagTTL adds a metadata tag that expires after ttl, as measured by the clock.
+// Once expired, GetTag and HasTag treat the tag as absent. A nil clock uses the global clock, see SetGlobalClock.
+func (b *BaseBuilder) WithTagTTL(key, value string, ttl time.Duration, clock Clock) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	clock = clockOrGlobal(clock)
+	b.WithTag(key, value)
+	if b.tagExpiries == nil {
+		b.tagExpiries = make(map[string]tagExpiry)
+	}
+	b.tagExpiries[key] = tagExpiry{at: clock.Now().Add(ttl), clock: clock}
+	return b
+}
+
+// AppendTag appends a value to a separator-delimited tag, through WithTag.
+// If the tag doesn't exist it is set directly; values already present are not duplicated.
+// An empty separator is recorded as an error.
+func (b *BaseBuilder) AppendTag(key, value, sep string) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if sep == "" {
+		return b.AddError(fmt.Errorf("cannot append to tag '%s' with an empty separator", key))
+	}
+	existing := b.GetTag(key)
+	if existing == "" {
+		return b.WithTag(key, value)
+	}
+	if slices.Contains(strings.Split(existing, sep), value) {
+		return b
+	}
+	return b.WithTag(key, existing+sep+value)
+}
+
+// WithScenario labels the builder with a scenario name and the current Unix time of the global clock.
+// If the SuiteEnvVar environment variable is set, a suite tag is added as well.
+func (b *BaseBuilder) WithScenario(name string) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.WithTag(ScenarioTagKey, name)
+	b.WithTag(ScenarioTimestampTagKey, strconv.FormatInt(clockOrGlobal(nil).Now().Unix(), 10))
+	if suite, ok := os.LookupEnv(SuiteEnvVar); ok {
+		b.WithTag(SuiteTagKey, suite)
+	}
+	return b
+}
+
+// WithRandomTags adds count tags with random keys and values drawn from a small alphabet.
+// Keys are unique within the generated set, and the set is deterministic for a given rng.
+// A nil rng uses an unseeded source. This is meant for fuzzing tag-processing code.
+func (b *BaseBuilder) WithRandomTags(count int, rng *rand.Rand) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec // test data, not security sensitive
+	}
+	generated := make(map[string]bool, max(count, 0))
+	for len(generated) < count {
+		key := randomString(rng, randomTagKeyLength)
+		if generated[key] {
+			continue
+		}
+		generated[key] = true
+		b.WithTag(key, randomString(rng, randomTagValueLength))
+	}
 	return b
 }
 
+// randomString returns a string of the given length drawn from randomTagAlphabet.
+func randomString(rng *rand.Rand, length int) string {
+	buf := make([]byte, length)
+	for i := range buf {
+		buf[i] = randomTagAlphabet[rng.IntN(len(randomTagAlphabet))]
+	}
+	return string(buf)
+}
+
 // GetTag retrieves a metadata tag value by key.
 // Returns empty string if the tag doesn't exist.
 func (b *BaseBuilder) GetTag(key string) string {
-	if b.tags == nil {
+	if b.tags == nil || b.isTagExpired(key) {
 		return ""
 	}
 	return b.tags[key]
 }
 
+// GetTags returns a copy of all unexpired metadata tags.
+func (b *BaseBuilder) GetTags() map[string]string {
+	tags := make(map[string]string, len(b.tags))
+	for key, value := range b.tags {
+		if !b.isTagExpired(key) {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
 // HasTag checks if a metadata tag exists.
 func (b *BaseBuilder) HasTag(key string) bool {
-	if b.tags == nil {
+	if b.tags == nil || b.isTagExpired(key) {
 		return false
 	}
 	_, exists := b.tags[key]
 	return exists
 }
 
+// isTagExpired reports whether a tag set with WithTagTTL has expired.
+func (b *BaseBuilder) isTagExpired(key string) bool {
+	expiry, exists := b.tagExpiries[key]
+	return exists && expiry.expired()
+}
+
+// InheritFrom copies the parent's tags and validation setting into the receiver, without copying entity data.
+// The parent is accessed through BaseBuilderAccessor, or reflectively through GetTags and IsValidationEnabled.
+func (b *BaseBuilder) InheritFrom(parent Builder) *BaseBuilder {
+	if !b.Mutable() || parent == nil {
+		return b
+	}
+
+	var tags map[string]string
+	validationEnabled := b.validationEnabled
+	if accessor, ok := parent.(BaseBuilderAccessor); ok && accessor.Base() != nil {
+		tags = accessor.Base().GetTags()
+		validationEnabled = accessor.Base().IsValidationEnabled()
+	} else {
+		parentValue := reflect.ValueOf(parent)
+		if method := parentValue.MethodByName("GetTags"); method.IsValid() && method.Type().NumIn() == 0 {
+			tags, _ = method.Call(nil)[0].Interface().(map[string]string)
+		}
+		if method := parentValue.MethodByName("IsValidationEnabled"); method.IsValid() && method.Type().NumIn() == 0 {
+			validationEnabled, _ = method.Call(nil)[0].Interface().(bool)
+		}
+	}
+
+	for key, value := range tags {
+		b.WithTag(key, value)
+	}
+	b.validationEnabled = validationEnabled
+	return b
+}
+
 // WithValidation enables or disables validation for this builder.
 func (b *BaseBuilder) WithValidation(enabled bool) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	b.validationEnabled = enabled
+	b.recordAudit("WithValidation", "validation", enabled)
 	return b
 }
 
@@ -77,12 +408,25 @@ func (b *BaseBuilder) IsValidationEnabled() bool {
 
 // AddError adds an error to the builder's error collection.
 func (b *BaseBuilder) AddError(err error) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if err != nil {
 		b.errors = append(b.errors, err)
+		if b.errorHandler != nil {
+			b.errorHandler(err)
+		}
 	}
 	return b
 }
 
+// OnError installs a handler invoked synchronously by AddError after each error is appended.
+// Useful to log errors as they occur or to fail fast in strict mode.
+func (b *BaseBuilder) OnError(fn func(error)) *BaseBuilder {
+	b.errorHandler = fn
+	return b
+}
+
 // GetErrors returns all errors accumulated by the builder.
 func (b *BaseBuilder) GetErrors() []error {
 	return b.errors
@@ -99,33 +443,622 @@ func (b *BaseBuilder) ClearErrors() *BaseBuilder {
 	return b
 }
 
+// ValidationSnapshot returns the messages of the current errors, sorted, so that two snapshots can be
+// compared with DiffValidation when investigating why a fixture started failing.
+func (b *BaseBuilder) ValidationSnapshot() []string {
+	messages := make([]string, 0, len(b.errors))
+	for _, err := range b.errors {
+		messages = append(messages, err.Error())
+	}
+	slices.Sort(messages)
+	return messages
+}
+
+// ExplainErrors returns a remediation hint for each recorded error caused by a known sentinel error,
+// e.g. "call WithEmail with a non-empty address" for ErrEmailRequired. Other errors are skipped.
+func (b *BaseBuilder) ExplainErrors() []string {
+	return explainErrors(errors.Join(b.errors...))
+}
+
+// AddWarning adds a non-fatal warning to the builder's warning collection.
+// Unlike errors, warnings don't prevent the builder from building.
+func (b *BaseBuilder) AddWarning(warning error) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if warning != nil {
+		b.warnings = append(b.warnings, warning)
+	}
+	return b
+}
+
+// GetWarnings returns all warnings accumulated by the builder.
+func (b *BaseBuilder) GetWarnings() []error {
+	return b.warnings
+}
+
+// HasWarnings returns true if the builder has any warnings.
+func (b *BaseBuilder) HasWarnings() bool {
+	return len(b.warnings) > 0
+}
+
+// ClearWarnings removes all warnings from the builder.
+func (b *BaseBuilder) ClearWarnings() *BaseBuilder {
+	b.warnings = make([]error, 0)
+	return b
+}
+
+// AddValidator registers a custom validation rule run at build time when validation is enabled.
+func (b *BaseBuilder) AddValidator(name string, fn ValidatorFunc) *BaseBuilder {
+	return b.AddValidatorInGroup(DefaultValidationGroup, name, fn)
+}
+
+// AddValidatorInGroup registers a custom validation rule in a named group, e.g. "create" or "update".
+// Build only runs the DefaultValidationGroup; other groups are run on demand with ValidateGroup.
+func (b *BaseBuilder) AddValidatorInGroup(group, name string, fn ValidatorFunc) *BaseBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	b.validators = append(b.validators, namedValidator{group: group, name: name, fn: fn})
+	return b
+}
+
+// ValidateGroup runs only the validators registered in group, with the builder itself as target.
+// Builders embedding BaseBuilder should shadow it to pass themselves as the target.
+func (b *BaseBuilder) ValidateGroup(group string) error {
+	return b.runValidatorGroup(b, group)
+}
+
+// ValidateAsync runs the DefaultValidationGroup validators concurrently, with the builder itself as target,
+// and aggregates their errors. Up to a fixed number of validators run at once, which speeds up
+// independent expensive checks such as network lookups; Build keeps running validators sequentially.
+// Builders embedding BaseBuilder should shadow it to pass themselves as the target.
+func (b *BaseBuilder) ValidateAsync(ctx context.Context) error {
+	return b.validateAsync(ctx, b, DefaultValidationGroup)
+}
+
+// WithValidationTimeout bounds how long custom validators may run, in Build, ValidateGroup and ValidateAsync.
+// Past d, the build stops waiting and fails with ErrValidationTimeout. Validators don't receive a context,
+// so a timed out validator can't be cancelled: it keeps running in the background until it returns.
+// A non-positive d removes the bound.
+func (b *BaseBuilder) WithValidationTimeout(d time.Duration) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.validationTimeout = max(d, 0)
+	return b
+}
+
+// validateAsync runs the validators of a group concurrently against target.
+// If ctx is done first, it returns the context error without waiting: validators don't receive ctx,
+// so running ones can't be interrupted and finish in the background, but no further ones are started.
+func (b *BaseBuilder) validateAsync(ctx context.Context, target Builder, group string) error {
+	if b.validationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, b.validationTimeout, b.timeoutError())
+		defer cancel()
+	}
+
+	validators, validationContext := b.groupValidators(group), b.validationContext
+	errs := make([]error, len(validators))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(asyncValidationWorkers, len(validators)) {
+		wg.Go(func() {
+			for i := range indexes {
+				if err := validators[i].fn(target, validationContext); err != nil {
+					errs[i] = fmt.Errorf("validator '%s': %w", validators[i].name, err)
+				}
+			}
+		})
+	}
+	go func() {
+		defer close(indexes)
+		for i := range validators {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return errors.Join(errs...)
+	case <-ctx.Done():
+		return fmt.Errorf("validation interrupted: %w", context.Cause(ctx))
+	}
+}
+
+// SetValidationContext sets external data passed to custom validators,
+// e.g. a set of reserved usernames loaded from a service.
+func (b *BaseBuilder) SetValidationContext(ctx map[string]any) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.validationContext = ctx
+	return b
+}
+
+// RunValidators runs the DefaultValidationGroup validators against target, which should be the outermost builder.
+// Builders embedding *BaseBuilder should call it from their Build method when validation is enabled,
+// so validators added with AddValidator apply to them.
+func (b *BaseBuilder) RunValidators(target Builder) error {
+	return b.runValidatorGroup(target, DefaultValidationGroup)
+}
+
+// runValidatorGroup runs the validators of a group against target, aggregating their errors.
+// With a validation timeout, it stops waiting for the validators once the timeout elapses.
+func (b *BaseBuilder) runValidatorGroup(target Builder, group string) error {
+	validators, validationContext := b.groupValidators(group), b.validationContext
+	if b.validationTimeout <= 0 {
+		return runValidatorsInOrder(target, validators, validationContext)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- runValidatorsInOrder(target, validators, validationContext)
+	}()
+	timer := time.NewTimer(b.validationTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		return b.timeoutError()
+	}
+}
+
+// groupValidators returns the validators registered in group, in registration order.
+func (b *BaseBuilder) groupValidators(group string) []namedValidator {
+	var validators []namedValidator
+	for _, validator := range b.validators {
+		if validator.group == group {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// timeoutError returns the error reported when validators exceed the validation timeout.
+func (b *BaseBuilder) timeoutError() error {
+	return fmt.Errorf("validators did not finish within %v: %w", b.validationTimeout, ErrValidationTimeout)
+}
+
+// runValidatorsInOrder runs validators sequentially against target, aggregating their errors.
+func runValidatorsInOrder(target Builder, validators []namedValidator, validationContext map[string]any) error {
+	var errs []error
+	for _, validator := range validators {
+		if err := validator.fn(target, validationContext); err != nil {
+			errs = append(errs, fmt.Errorf("validator '%s': %w", validator.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithValidationProfile selects a validation profile registered with RegisterValidationProfile.
+// At build time, the profile's rules replace the builder's built-in rules; custom validators still run.
+// An empty name restores the built-in rules.
+func (b *BaseBuilder) WithValidationProfile(name string) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.validationProfile = name
+	return b
+}
+
+// WithEnvironment configures the builder for the "test", "staging" or "ci" environment in one call.
+// It sets the EnvTagKey tag, enables validation, and selects the "relaxed" validation profile in test,
+// the built-in rules in staging, and the "strict" profile in ci. Unknown environments add an error.
+func (b *BaseBuilder) WithEnvironment(env string) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	profile, exists := environmentProfiles[env]
+	if !exists {
+		return b.AddError(fmt.Errorf("unknown environment '%s', expected one of %s",
+			env, strings.Join(slices.Sorted(maps.Keys(environmentProfiles)), ", ")))
+	}
+	b.WithTag(EnvTagKey, env)
+	b.validationEnabled = true
+	b.validationProfile = profile
+	return b
+}
+
+// WithSerialGate serializes the builder's builds with those of every other builder sharing g,
+// in call order, so gated builds drawing from a shared Sequence get values in a stable order.
+func (b *BaseBuilder) WithSerialGate(g *SerialGate) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.gate = g
+	return b
+}
+
+// EnterGate waits for the builder's turn in its serial gate, if any, and returns the function ending it.
+// Builders embedding *BaseBuilder should call it at the start of their Build method, deferring the returned
+// function, so WithSerialGate applies to them.
+func (b *BaseBuilder) EnterGate() func() {
+	if b.gate == nil {
+		return func() {}
+	}
+	return b.gate.enter()
+}
+
+// runValidationProfile runs the rules of the selected validation profile against target.
+// It reports false when no profile is selected, so the builder applies its built-in rules instead.
+func (b *BaseBuilder) runValidationProfile(target Builder) (bool, error) {
+	if b.validationProfile == "" {
+		return false, nil
+	}
+	rules, exists := validationProfile(b.validationProfile)
+	if !exists {
+		return true, fmt.Errorf("validation profile '%s' is not registered", b.validationProfile)
+	}
+	var errs []error
+	for _, rule := range rules {
+		if err := rule(target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return true, errors.Join(errs...)
+}
+
+// SetErrorFormatter installs a formatter used by validators to produce error messages.
+// Passing nil restores the default English messages.
+func (b *BaseBuilder) SetErrorFormatter(formatter ErrorFormatter) *BaseBuilder {
+	b.errorFormatter = formatter
+	return b
+}
+
+// formatError returns the validation message for a field and rule,
+// using the installed formatter or falling back to the default message.
+func (b *BaseBuilder) formatError(field, rule string, value any, defaultMessage string) string {
+	if b.errorFormatter == nil {
+		return defaultMessage
+	}
+	return b.errorFormatter(field, rule, value)
+}
+
+// Freeze locks the builder against further mutations.
+// Once frozen, With* and AddError calls become no-ops and a single ErrBuilderFrozen is recorded.
+func (b *BaseBuilder) Freeze() *BaseBuilder {
+	b.frozen = true
+	return b
+}
+
+// IsFrozen returns whether the builder is frozen.
+func (b *BaseBuilder) IsFrozen() bool {
+	return b.frozen
+}
+
+// WithAutoFreeze enables or disables freezing the builder automatically after each successful build,
+// so a failed build can still be corrected.
+func (b *BaseBuilder) WithAutoFreeze(enabled bool) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.autoFreeze = enabled
+	return b
+}
+
+// GuardAfterBuild marks the builder consumed after each successful build, to catch code that keeps calling With* methods
+// expecting them to affect an already built object. Mutations of a consumed builder are ignored and
+// a single ErrBuilderConsumed is recorded, until Reset is called. The guard stays enabled across Reset.
+func (b *BaseBuilder) GuardAfterBuild() *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.guardAfterBuild = true
+	return b
+}
+
+// Mutable reports whether the builder accepts mutations, marking it dirty when it does.
+// Builders embedding *BaseBuilder should call it at the start of every mutating method and
+// return early when it reports false, so Freeze, WithAutoFreeze and GuardAfterBuild apply to them.
+func (b *BaseBuilder) Mutable() bool {
+	if b.frozen {
+		if !b.frozenErrorRecorded {
+			b.errors = append(b.errors, ErrBuilderFrozen)
+			b.frozenErrorRecorded = true
+		}
+		return false
+	}
+	if b.consumed {
+		if !b.consumedErrorRecorded {
+			b.errors = append(b.errors, ErrBuilderConsumed)
+			b.consumedErrorRecorded = true
+		}
+		return false
+	}
+	b.dirty = true
+	return true
+}
+
+// EnableAudit starts recording every accepted mutation in an ordered audit trail, exposed by AuditTrail.
+// Auditing is disabled by default to keep builders cheap.
+func (b *BaseBuilder) EnableAudit() *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.auditEnabled = true
+	return b
+}
+
+// AuditTrail returns a copy of the mutations recorded since EnableAudit, oldest first.
+func (b *BaseBuilder) AuditTrail() []AuditEntry {
+	return slices.Clone(b.auditTrail)
+}
+
+// recordAudit appends a mutation to the audit trail if auditing is enabled.
+func (b *BaseBuilder) recordAudit(method, key string, value any) {
+	if !b.auditEnabled {
+		return
+	}
+	b.auditTrail = append(b.auditTrail, AuditEntry{Method: method, Key: key, Value: value, Timestamp: clockOrGlobal(nil).Now()})
+}
+
+// WithSimulatedLatency makes builds sleep for d before constructing the object.
+// It is intended for tests only, to exercise timeout and deadline handling with realistic delays.
+// Builds started with BuildContext stop sleeping early when the context is cancelled.
+func (b *BaseBuilder) WithSimulatedLatency(d time.Duration) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.latency = d
+	return b
+}
+
+// SimulateLatency sleeps for the configured latency, returning early with the context error on cancellation.
+// Builders embedding *BaseBuilder should call it from their Build method, so WithSimulatedLatency applies to them.
+func (b *BaseBuilder) SimulateLatency(ctx context.Context) error {
+	if b.latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(b.latency)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AddBeforeBuildHook registers a function run before the object is constructed. A hook error fails the build.
+func (b *BaseBuilder) AddBeforeBuildHook(fn func() error) *BaseBuilder {
+	if fn == nil {
+		return b
+	}
+	return b.AddBeforeBuildHookCtx(func(context.Context) error { return fn() })
+}
+
+// AddBeforeBuildHookCtx registers a context-aware before-build hook, for hooks doing I/O.
+// It receives the context passed to BuildContext, or context.Background() when built with Build.
+func (b *BaseBuilder) AddBeforeBuildHookCtx(fn func(ctx context.Context) error) *BaseBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	b.beforeBuildHooks = append(b.beforeBuildHooks, fn)
+	return b
+}
+
+// AddAfterBuildHook registers a function run on the built object after a successful build,
+// typically to create external state such as database rows. A hook error fails the build.
+func (b *BaseBuilder) AddAfterBuildHook(fn func(result any) error) *BaseBuilder {
+	if fn == nil {
+		return b
+	}
+	return b.AddAfterBuildHookCtx(func(_ context.Context, result any) error { return fn(result) })
+}
+
+// AddAfterBuildHookCtx registers a context-aware after-build hook, for hooks doing I/O.
+// It receives the context passed to BuildContext, or context.Background() when built with Build.
+func (b *BaseBuilder) AddAfterBuildHookCtx(fn func(ctx context.Context, result any) error) *BaseBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	b.afterBuildHooks = append(b.afterBuildHooks, fn)
+	return b
+}
+
+// AddUndoHook registers a function reverting the effects of the after-build hook registered last,
+// or of the first one registered if none was yet. Undo hooks are run in reverse registration order
+// when a BuildTx is rolled back, skipping those whose after-build hook didn't complete.
+func (b *BaseBuilder) AddUndoHook(fn func()) *BaseBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	b.undoHooks = append(b.undoHooks, undoHook{afterHook: max(len(b.afterBuildHooks)-1, 0), fn: fn})
+	return b
+}
+
+// RunBeforeBuildHooks runs the before-build hooks in registration order, stopping at the first error.
+// Builders embedding *BaseBuilder should call it from their Build method before constructing the object.
+func (b *BaseBuilder) RunBeforeBuildHooks(ctx context.Context) error {
+	for i, hook := range b.beforeBuildHooks {
+		if err := hook(ctx); err != nil {
+			return fmt.Errorf("before-build hook %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// RunAfterBuildHooks runs the after-build hooks in registration order, stopping at the first error.
+// Builders embedding *BaseBuilder should call it from their Build method once the object is built,
+// and return its error, so BuildTx can roll back the hooks that completed.
+func (b *BaseBuilder) RunAfterBuildHooks(ctx context.Context, result any) error {
+	for i, hook := range b.afterBuildHooks {
+		if err := hook(ctx, result); err != nil {
+			return fmt.Errorf("after-build hook %d: %w", i, err)
+		}
+		b.afterHooksRun = i + 1
+	}
+	return nil
+}
+
+// BuildCount returns how many times the builder was built successfully.
+// Useful for spotting accidental builder reuse across tests.
+func (b *BaseBuilder) BuildCount() int {
+	return b.buildCount
+}
+
+// ResetCount returns how many times the builder was reset.
+func (b *BaseBuilder) ResetCount() int {
+	return b.resetCount
+}
+
+// RecordBuild increments the build counter, and applies auto-freeze and the after-build guard.
+// Builders embedding *BaseBuilder should call it from their Build method once the build succeeded,
+// so BuildCount, WithAutoFreeze and GuardAfterBuild apply to them while a failed build can still be corrected.
+func (b *BaseBuilder) RecordBuild() {
+	b.buildCount++
+	if b.autoFreeze {
+		b.frozen = true
+	}
+	if b.guardAfterBuild {
+		b.consumed = true
+	}
+}
+
 // Build is a default implementation that returns nil.
 // Specific builders should override this method.
 func (b *BaseBuilder) Build() any {
+	b.RecordBuild()
 	return nil
 }
 
+// LinkReset links reset callbacks of shared resources, such as a Sequence's Reset, to the builder.
+// They are invoked in order on every Reset, so rebuilding a fixture set restarts its IDs.
+// Links survive Reset and are shared with clones.
+func (b *BaseBuilder) LinkReset(fns ...func()) *BaseBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	for _, fn := range fns {
+		if fn != nil {
+			b.linkedResets = append(b.linkedResets, fn)
+		}
+	}
+	return b
+}
+
 // Reset clears the builder state, allowing it to be reused.
+// The build and reset counters are preserved for diagnostics, and linked resets are invoked.
 func (b *BaseBuilder) Reset() Builder {
+	for _, fn := range b.linkedResets {
+		fn()
+	}
 	b.tags = make(map[string]string)
+	b.tagExpiries = nil
+	b.tagOrder = nil
+	b.tagLimit = 0
 	b.validationEnabled = true
 	b.errors = make([]error, 0)
+	b.warnings = make([]error, 0)
+	b.frozen = false
+	b.frozenErrorRecorded = false
+	b.autoFreeze = false
+	b.consumed = false
+	b.consumedErrorRecorded = false
+	b.errorFormatter = nil
+	b.validators = nil
+	b.validationContext = nil
+	b.validationTimeout = 0
+	b.validationProfile = ""
+	b.errorHandler = nil
+	b.latency = 0
+	b.gate = nil
+	b.beforeBuildHooks = nil
+	b.afterBuildHooks = nil
+	b.undoHooks = nil
+	b.afterHooksRun = 0
+	b.auditEnabled = false
+	b.auditTrail = nil
+	b.resetCount++
+	b.dirty = true
 	return b
 }
 
 // Clone creates a deep copy of the BaseBuilder.
+// The clone is a fresh builder, so its build and reset counters start at zero
+// and it is never frozen or consumed, even if the original is.
 func (b *BaseBuilder) Clone() Builder {
 	clone := &BaseBuilder{
 		tags:              make(map[string]string),
+		tagExpiries:       maps.Clone(b.tagExpiries),
+		tagOrder:          slices.Clone(b.tagOrder),
+		tagLimit:          b.tagLimit,
 		validationEnabled: b.validationEnabled,
+		autoFreeze:        b.autoFreeze,
+		guardAfterBuild:   b.guardAfterBuild,
+		errorFormatter:    b.errorFormatter,
+		validators:        slices.Clone(b.validators),
+		validationContext: maps.Clone(b.validationContext),
+		validationTimeout: b.validationTimeout,
+		validationProfile: b.validationProfile,
+		errorHandler:      b.errorHandler,
+		latency:           b.latency,
+		gate:              b.gate,
+		beforeBuildHooks:  slices.Clone(b.beforeBuildHooks),
+		afterBuildHooks:   slices.Clone(b.afterBuildHooks),
+		undoHooks:         slices.Clone(b.undoHooks),
+		linkedResets:      slices.Clone(b.linkedResets),
+		auditEnabled:      b.auditEnabled,
+		auditTrail:        slices.Clone(b.auditTrail),
 		errors:            make([]error, len(b.errors)),
+		warnings:          make([]error, len(b.warnings)),
 	}
 
 	// Deep copy tags
 	maps.Copy(clone.tags, b.tags)
 
-	// Deep copy errors
+	// Deep copy errors, dropping the frozen and consumed errors since the clone is neither
 	copy(clone.errors, b.errors)
+	clone.errors = slices.DeleteFunc(clone.errors, func(err error) bool {
+		return errors.Is(err, ErrBuilderFrozen) || errors.Is(err, ErrBuilderConsumed)
+	})
+
+	// Deep copy warnings
+	copy(clone.warnings, b.warnings)
 
 	return clone
 }
+
+// cloneConfig returns a copy of the builder's configuration, such as tags, validation settings, validators and hooks.
+// Errors, warnings and the audit trail describe entity data, so they are not copied.
+func (b *BaseBuilder) cloneConfig() *BaseBuilder {
+	clone, _ := b.Clone().(*BaseBuilder)
+	clone.errors = make([]error, 0)
+	clone.warnings = make([]error, 0)
+	clone.auditTrail = nil
+	return clone
+}
+
+// ScenarioOf returns the scenario name of any builder exposing a BaseBuilder or a GetTag(string) string method.
+// Returns empty string if the builder has no scenario or doesn't support tags.
+func ScenarioOf(b Builder) string {
+	if b == nil {
+		return ""
+	}
+	if accessor, ok := b.(BaseBuilderAccessor); ok && accessor.Base() != nil {
+		return accessor.Base().GetTag(ScenarioTagKey)
+	}
+	method := reflect.ValueOf(b).MethodByName("GetTag")
+	if !method.IsValid() {
+		return ""
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.In(0).Kind() != reflect.String ||
+		methodType.NumOut() != 1 || methodType.Out(0).Kind() != reflect.String {
+		return ""
+	}
+	return method.Call([]reflect.Value{reflect.ValueOf(ScenarioTagKey).Convert(methodType.In(0))})[0].String()
+}
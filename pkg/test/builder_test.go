@@ -2,7 +2,13 @@ package testkit //nolint:testpackage // tests require access to unexported field
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestBaseBuilder_NewBaseBuilder(t *testing.T) {
@@ -194,3 +200,451 @@ func TestBaseBuilder_NilSafety(t *testing.T) {
 		t.Error("WithTag should initialize tags map")
 	}
 }
+
+func TestBaseBuilder_AppendTag(t *testing.T) {
+	builder := NewBaseBuilder()
+
+	// Test first set
+	result := builder.AppendTag("roles", "admin", ",")
+	if result != builder {
+		t.Error("AppendTag should return the same builder instance")
+	}
+	if builder.GetTag("roles") != "admin" {
+		t.Errorf("Expected 'admin', got '%s'", builder.GetTag("roles"))
+	}
+
+	// Test append
+	builder.AppendTag("roles", "editor", ",")
+	if builder.GetTag("roles") != "admin,editor" {
+		t.Errorf("Expected 'admin,editor', got '%s'", builder.GetTag("roles"))
+	}
+
+	// Test duplicate suppression
+	builder.AppendTag("roles", "admin", ",")
+	builder.AppendTag("roles", "editor", ",")
+	if builder.GetTag("roles") != "admin,editor" {
+		t.Errorf("Expected duplicates to be suppressed, got '%s'", builder.GetTag("roles"))
+	}
+
+	// Appends are audited like any other tag write
+	audited := NewBaseBuilder().EnableAudit().AppendTag("roles", "admin", ",").AppendTag("roles", "editor", ",")
+	trail := audited.AuditTrail()
+	if len(trail) != 2 || trail[1].Method != "WithTag" || trail[1].Value != "admin,editor" {
+		t.Errorf("Expected appended tags in the audit trail, got %+v", trail)
+	}
+
+	// An empty separator is rejected
+	builder.AppendTag("roles", "viewer", "")
+	if !builder.HasErrors() || builder.GetTag("roles") != "admin,editor" {
+		t.Errorf("Expected an error and an unchanged tag for an empty separator, got '%s'", builder.GetTag("roles"))
+	}
+}
+
+func TestBaseBuilder_Counters(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.WithName("John Doe").WithEmail("john@example.com")
+
+	for range 3 {
+		builder.Build()
+	}
+	builder.Reset()
+	builder.Reset()
+
+	if builder.BuildCount() != 3 {
+		t.Errorf("Expected build count 3, got %d", builder.BuildCount())
+	}
+	if builder.ResetCount() != 2 {
+		t.Errorf("Expected reset count 2, got %d", builder.ResetCount())
+	}
+
+	// Clone should start with fresh counters
+	clone, ok := builder.Clone().(*UserBuilder)
+	if !ok {
+		t.Fatal("Expected UserBuilder clone")
+	}
+	if clone.BuildCount() != 0 || clone.ResetCount() != 0 {
+		t.Error("Clone should have its counters reset to zero")
+	}
+}
+
+func TestBaseBuilder_Warnings(t *testing.T) {
+	builder := NewBaseBuilder()
+
+	if builder.HasWarnings() {
+		t.Error("Expected no warnings initially")
+	}
+
+	builder.AddWarning(nil)
+	if builder.HasWarnings() {
+		t.Error("Adding nil warning should not add to warnings")
+	}
+
+	builder.AddWarning(errors.New("test warning"))
+	if !builder.HasWarnings() || len(builder.GetWarnings()) != 1 {
+		t.Error("Expected one warning after adding")
+	}
+	if builder.HasErrors() {
+		t.Error("Warnings should not be reported as errors")
+	}
+
+	clone, ok := builder.Clone().(*BaseBuilder)
+	if !ok {
+		t.Fatal("Clone should return a BaseBuilder instance")
+	}
+	if !clone.HasWarnings() {
+		t.Error("Clone should have the same warnings")
+	}
+
+	builder.ClearWarnings()
+	if builder.HasWarnings() {
+		t.Error("Expected no warnings after clearing")
+	}
+
+	builder.AddWarning(errors.New("test warning"))
+	builder.Reset()
+	if builder.HasWarnings() {
+		t.Error("Expected warnings to be cleared after reset")
+	}
+}
+
+func TestBaseBuilder_Freeze(t *testing.T) {
+	builder := NewBaseBuilder()
+	builder.WithTag("env", "test")
+
+	result := builder.Freeze()
+	if result != builder {
+		t.Error("Freeze should return the same builder instance")
+	}
+	if !builder.IsFrozen() {
+		t.Error("Expected builder to be frozen")
+	}
+
+	builder.WithTag("env", "prod")
+	builder.WithValidation(false)
+	builder.AddError(errors.New("ignored"))
+
+	if builder.GetTag("env") != "test" {
+		t.Error("Expected tag mutation to be rejected after Freeze")
+	}
+	if !builder.IsValidationEnabled() {
+		t.Error("Expected validation mutation to be rejected after Freeze")
+	}
+	errs := builder.GetErrors()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrBuilderFrozen) {
+		t.Errorf("Expected a single frozen error, got %v", errs)
+	}
+
+	// Clone returns an unfrozen copy
+	clone, ok := builder.Clone().(*BaseBuilder)
+	if !ok {
+		t.Fatal("Clone should return a BaseBuilder instance")
+	}
+	if clone.IsFrozen() || clone.HasErrors() {
+		t.Error("Clone of a frozen builder should be unfrozen and without the frozen error")
+	}
+	clone.WithTag("env", "prod")
+	if clone.GetTag("env") != "prod" {
+		t.Error("Expected clone to accept mutations")
+	}
+}
+
+func TestBaseBuilder_WithAutoFreeze(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.WithName("John Doe").WithEmail("john@example.com").WithAutoFreeze(true)
+
+	if builder.IsFrozen() {
+		t.Error("Expected builder not to be frozen before build")
+	}
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Fatal("Expected build to succeed")
+	}
+	if !builder.IsFrozen() {
+		t.Fatal("Expected builder to be frozen after build")
+	}
+
+	builder.WithName("Modified")
+	if builder.user.Name != "John Doe" {
+		t.Error("Expected UserBuilder mutation to be rejected after auto-freeze")
+	}
+	if !builder.HasErrors() {
+		t.Error("Expected frozen error to be recorded")
+	}
+
+	builder.Reset()
+	if builder.IsFrozen() {
+		t.Error("Expected reset to unfreeze the builder")
+	}
+
+	failing := NewUserBuilder().WithName("Jane")
+	failing.WithAutoFreeze(true)
+	if _, isErr := failing.Build().(error); !isErr {
+		t.Fatal("Expected the build without an email to fail")
+	}
+	if failing.IsFrozen() {
+		t.Error("Expected a failed build not to freeze the builder")
+	}
+	failing.WithEmail("jane@example.com")
+	if _, ok := failing.Build().(*TestUser); !ok || !failing.IsFrozen() {
+		t.Error("Expected the corrected build to succeed and freeze the builder")
+	}
+}
+
+func TestBaseBuilder_WithScenario(t *testing.T) {
+	t.Setenv(SuiteEnvVar, "integration")
+
+	before := time.Now().Unix()
+	builder := NewUserBuilder()
+	builder.WithScenario("checkout")
+
+	if builder.GetTag(ScenarioTagKey) != "checkout" {
+		t.Errorf("Expected scenario tag 'checkout', got %q", builder.GetTag(ScenarioTagKey))
+	}
+	timestamp, err := strconv.ParseInt(builder.GetTag(ScenarioTimestampTagKey), 10, 64)
+	if err != nil || timestamp < before {
+		t.Errorf("Expected a current Unix timestamp, got %q", builder.GetTag(ScenarioTimestampTagKey))
+	}
+	if builder.GetTag(SuiteTagKey) != "integration" {
+		t.Errorf("Expected suite tag from env, got %q", builder.GetTag(SuiteTagKey))
+	}
+
+	if ScenarioOf(builder) != "checkout" {
+		t.Errorf("Expected ScenarioOf to return 'checkout', got %q", ScenarioOf(builder))
+	}
+	if ScenarioOf(nil) != "" {
+		t.Error("Expected ScenarioOf(nil) to return empty string")
+	}
+	if ScenarioOf(indexedTagBuilder{}) != "" {
+		t.Error("Expected ScenarioOf to ignore a GetTag method with another signature")
+	}
+}
+
+// indexedTagBuilder has a GetTag method not taking a tag key.
+type indexedTagBuilder struct{}
+
+func (indexedTagBuilder) Build() any              { return nil }
+func (indexedTagBuilder) Reset() Builder          { return indexedTagBuilder{} }
+func (indexedTagBuilder) Clone() Builder          { return indexedTagBuilder{} }
+func (indexedTagBuilder) GetTag(index int) string { return strconv.Itoa(index) }
+
+func TestBaseBuilder_WithTagTTL(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	builder := NewBaseBuilder()
+	builder.WithTagTTL("token", "abc", time.Minute, clock)
+	builder.WithTag("env", "test")
+
+	if builder.GetTag("token") != "abc" || !builder.HasTag("token") {
+		t.Error("Expected tag to be present before expiry")
+	}
+
+	clock.Advance(59 * time.Second)
+	if !builder.HasTag("token") {
+		t.Error("Expected tag to be present just before expiry")
+	}
+
+	clock.Advance(time.Second)
+	if builder.GetTag("token") != "" || builder.HasTag("token") {
+		t.Error("Expected tag to disappear once the TTL elapsed")
+	}
+	if !builder.HasTag("env") {
+		t.Error("Expected tags without TTL to be unaffected")
+	}
+
+	// Setting the tag again without TTL removes the expiry
+	builder.WithTag("token", "def")
+	if builder.GetTag("token") != "def" {
+		t.Error("Expected plain WithTag to clear the expiry")
+	}
+}
+
+func TestBaseBuilder_OnError(t *testing.T) {
+	var received []error
+	builder := NewBaseBuilder()
+	result := builder.OnError(func(err error) {
+		received = append(received, err)
+	})
+	if result != builder {
+		t.Error("OnError should return the same builder instance")
+	}
+
+	testError := errors.New("test error")
+	builder.AddError(testError)
+	builder.AddError(nil)
+
+	if len(received) != 1 || !errors.Is(received[0], testError) {
+		t.Errorf("Expected handler to receive exactly the added error, got %v", received)
+	}
+
+	// The handler is copied in Clone
+	clone, ok := builder.Clone().(*BaseBuilder)
+	if !ok {
+		t.Fatal("Clone should return a BaseBuilder instance")
+	}
+	clone.AddError(errors.New("clone error"))
+	if len(received) != 2 {
+		t.Error("Expected the cloned handler to fire")
+	}
+
+	// The handler is cleared in Reset
+	builder.Reset()
+	builder.AddError(errors.New("after reset"))
+	if len(received) != 2 {
+		t.Error("Expected handler to be cleared after reset")
+	}
+}
+
+func TestBaseBuilder_WithRandomTags(t *testing.T) {
+	newRng := func() *rand.Rand { return rand.New(rand.NewPCG(42, 42)) } //nolint:gosec // deterministic test data
+
+	first := NewBaseBuilder().WithRandomTags(5, newRng())
+	second := NewBaseBuilder().WithRandomTags(5, newRng())
+
+	if len(first.tags) != 5 {
+		t.Fatalf("Expected 5 random tags, got %d", len(first.tags))
+	}
+	if !maps.Equal(first.tags, second.tags) {
+		t.Errorf("Expected the same seed to produce the same tags, got %v and %v", first.tags, second.tags)
+	}
+	for key, value := range first.tags {
+		if len(key) != randomTagKeyLength || len(value) != randomTagValueLength {
+			t.Errorf("Unexpected random tag %q=%q", key, value)
+		}
+	}
+
+	if tags := NewBaseBuilder().WithRandomTags(0, newRng()).tags; len(tags) != 0 {
+		t.Errorf("Expected no tags for a zero count, got %v", tags)
+	}
+}
+
+func TestBaseBuilder_WithTagLimit(t *testing.T) {
+	builder := NewBaseBuilder().WithTagLimit(3)
+	builder.WithTag("a", "1").WithTag("b", "2").WithTag("c", "3")
+	builder.WithTag("a", "updated") // overwriting keeps the original position
+	builder.WithTag("d", "4").WithTag("e", "5")
+
+	if order := builder.TagInsertionOrder(); !slices.Equal(order, []string{"c", "d", "e"}) {
+		t.Errorf("Expected the oldest tags to be evicted, got order %v", order)
+	}
+	if builder.HasTag("a") || builder.HasTag("b") || len(builder.GetTags()) != 3 {
+		t.Errorf("Expected only the last 3 tags to remain, got %v", builder.GetTags())
+	}
+
+	builder.WithTagLimit(1)
+	if order := builder.TagInsertionOrder(); !slices.Equal(order, []string{"e"}) {
+		t.Errorf("Expected lowering the limit to evict immediately, got order %v", order)
+	}
+
+	clone, _ := builder.Clone().(*BaseBuilder)
+	clone.WithTag("f", "6")
+	if !clone.HasTag("f") || clone.HasTag("e") || !builder.HasTag("e") {
+		t.Error("Expected the clone to keep the limit independently of the original")
+	}
+
+	unlimited := NewBaseBuilder().WithTagLimit(0)
+	for i := range 10 {
+		unlimited.WithTag(strconv.Itoa(i), "v")
+	}
+	if len(unlimited.TagInsertionOrder()) != 10 {
+		t.Error("Expected a zero limit to keep every tag")
+	}
+}
+
+type taggedParentBuilder struct{}
+
+func (taggedParentBuilder) Build() any                 { return nil }
+func (p taggedParentBuilder) Reset() Builder           { return p }
+func (p taggedParentBuilder) Clone() Builder           { return p }
+func (taggedParentBuilder) GetTags() map[string]string { return map[string]string{"team": "payments"} }
+func (taggedParentBuilder) IsValidationEnabled() bool  { return false }
+
+func TestBaseBuilder_InheritFrom(t *testing.T) {
+	parent := NewUserBuilder().WithName("Parent").WithEmail("parent@example.com")
+	parent.WithTag("env", "staging").WithValidation(false)
+
+	child := NewUserBuilder()
+	child.WithTag("role", "child")
+	child.InheritFrom(parent)
+
+	if child.GetTag("env") != "staging" || child.GetTag("role") != "child" {
+		t.Errorf("Expected inherited and own tags, got %v", child.GetTags())
+	}
+	if child.IsValidationEnabled() {
+		t.Error("Expected validation setting to be inherited")
+	}
+	if child.IsFieldSet("name") || child.user.Name != "" {
+		t.Error("Expected entity data not to be inherited")
+	}
+
+	parent.WithTag("env", "production")
+	if child.GetTag("env") != "staging" {
+		t.Error("Expected inherited tags to be copied, not shared")
+	}
+
+	reflective := NewBaseBuilder().InheritFrom(taggedParentBuilder{})
+	if reflective.GetTag("team") != "payments" || reflective.IsValidationEnabled() {
+		t.Error("Expected tags and validation to be inherited reflectively")
+	}
+}
+
+func TestBaseBuilder_ExplainErrors(t *testing.T) {
+	tests := []struct {
+		sentinel error
+		hint     string
+	}{
+		{sentinel: ErrNameRequired, hint: "call WithName with a non-empty name"},
+		{sentinel: ErrEmailRequired, hint: "call WithEmail with a non-empty address"},
+		{sentinel: ErrNegativeAge, hint: "call WithAge with zero or a positive age, or WithClampedAge to clamp it into range"},
+		{sentinel: ErrNegativeID, hint: "call WithID with zero or a positive ID"},
+		{sentinel: ErrBuilderFrozen, hint: "call Clone to get a mutable copy instead of changing a frozen builder"},
+		{sentinel: ErrBuilderConsumed, hint: "call Reset before configuring a built builder again, or configure a Clone"},
+		{sentinel: ErrCyclicReference, hint: "remove one of the builder references forming the cycle"},
+		{sentinel: ErrBuildTimeout, hint: "increase the timeout or remove the simulated latency of the builder"},
+		{sentinel: ErrSequenceExhausted, hint: "create the jittered sequence with a larger count"},
+		{sentinel: ErrValidationTimeout, hint: "speed up the slow validator or raise the limit set with WithValidationTimeout"},
+	}
+
+	for _, tt := range tests {
+		builder := NewBaseBuilder()
+		builder.AddError(fmt.Errorf("wrapped: %w", tt.sentinel))
+		explanations := builder.ExplainErrors()
+		expected := "wrapped: " + tt.sentinel.Error() + ": " + tt.hint
+		if len(explanations) != 1 || explanations[0] != expected {
+			t.Errorf("Expected %q for %v, got %v", expected, tt.sentinel, explanations)
+		}
+	}
+
+	builder := NewBaseBuilder()
+	builder.AddError(errors.New("unknown"))
+	if explanations := builder.ExplainErrors(); len(explanations) != 0 {
+		t.Errorf("Expected unknown errors to be skipped, got %v", explanations)
+	}
+}
+
+func TestBaseBuilder_WithEnvironment(t *testing.T) {
+	tests := []struct {
+		env     string
+		profile string
+	}{
+		{env: "test", profile: "relaxed"},
+		{env: "staging", profile: ""},
+		{env: "ci", profile: "strict"},
+	}
+
+	for _, tt := range tests {
+		builder := NewBaseBuilder().WithValidation(false).WithValidationProfile("other").WithEnvironment(tt.env)
+		if builder.GetTag(EnvTagKey) != tt.env {
+			t.Errorf("%s: expected the env tag, got %q", tt.env, builder.GetTag(EnvTagKey))
+		}
+		if !builder.IsValidationEnabled() || builder.validationProfile != tt.profile {
+			t.Errorf("%s: expected validation with profile %q, got enabled=%v profile=%q",
+				tt.env, tt.profile, builder.IsValidationEnabled(), builder.validationProfile)
+		}
+		if builder.HasErrors() {
+			t.Errorf("%s: expected no errors, got %v", tt.env, builder.GetErrors())
+		}
+	}
+
+	builder := NewBaseBuilder().WithEnvironment("production")
+	if !builder.HasErrors() || builder.HasTag(EnvTagKey) {
+		t.Error("Expected an error and no tag for an unknown environment")
+	}
+}
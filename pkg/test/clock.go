@@ -0,0 +1,85 @@
+package testkit
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time, allowing time-dependent builders to be tested deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+//nolint:gochecknoglobals // process-wide clock override, guarded by globalClockMu
+var (
+	globalClockMu sync.RWMutex
+	globalClock   Clock = RealClock{}
+)
+
+// SetGlobalClock replaces the clock used by time-based helpers when they are passed a nil clock.
+// The override is process-global and meant for tests only: tests changing it must not run in parallel
+// and should restore the system time with ResetGlobalClock, e.g. via t.Cleanup. A nil clock resets it.
+func SetGlobalClock(c Clock) {
+	if c == nil {
+		c = RealClock{}
+	}
+	globalClockMu.Lock()
+	defer globalClockMu.Unlock()
+	globalClock = c
+}
+
+// ResetGlobalClock restores the system time as the global clock.
+func ResetGlobalClock() {
+	SetGlobalClock(nil)
+}
+
+// clockOrGlobal returns clock, or the global clock set with SetGlobalClock if clock is nil.
+func clockOrGlobal(clock Clock) Clock {
+	if clock != nil {
+		return clock
+	}
+	globalClockMu.RLock()
+	defer globalClockMu.RUnlock()
+	return globalClock
+}
+
+// RealClock is a Clock backed by the system time.
+type RealClock struct{}
+
+// Now returns the current system time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a manually controlled Clock for tests.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a new FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the fake time forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the fake time to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
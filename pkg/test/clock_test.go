@@ -0,0 +1,67 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v, got %v", start, clock.Now())
+	}
+
+	clock.Advance(time.Hour)
+	if !clock.Now().Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected clock to advance by an hour, got %v", clock.Now())
+	}
+
+	later := start.AddDate(1, 0, 0)
+	clock.Set(later)
+	if !clock.Now().Equal(later) {
+		t.Errorf("Expected %v, got %v", later, clock.Now())
+	}
+}
+
+func TestRealClock(t *testing.T) {
+	before := time.Now()
+	now := RealClock{}.Now()
+	if now.Before(before) {
+		t.Error("Expected RealClock to return the current time")
+	}
+}
+
+func TestSetGlobalClock(t *testing.T) {
+	fixed := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	SetGlobalClock(NewFakeClock(fixed))
+	t.Cleanup(ResetGlobalClock)
+
+	user, ok := NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithCreatedAt(nil).
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user to build successfully")
+	}
+	if createdAt, _ := user.Metadata[CreatedAtMetadataKey].(time.Time); !createdAt.Equal(fixed) {
+		t.Errorf("Expected created_at %v, got %v", fixed, user.Metadata[CreatedAtMetadataKey])
+	}
+
+	explicit := fixed.AddDate(0, 0, 1)
+	user, _ = NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithCreatedAt(NewFakeClock(explicit)).
+		Build().(*TestUser)
+	if createdAt, _ := user.Metadata[CreatedAtMetadataKey].(time.Time); !createdAt.Equal(explicit) {
+		t.Errorf("Expected an explicit clock to take precedence, got %v", user.Metadata[CreatedAtMetadataKey])
+	}
+
+	ResetGlobalClock()
+	if _, isReal := clockOrGlobal(nil).(RealClock); !isReal {
+		t.Error("Expected ResetGlobalClock to restore the real clock")
+	}
+}
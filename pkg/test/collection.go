@@ -0,0 +1,121 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// CollectionBuilder builds a batch of entities of type T, creating one builder per element.
+type CollectionBuilder[T any] struct {
+	count      int
+	newBuilder func(i int) Builder
+	workers    int
+	progress   func(done, total int)
+	dedupKey   func(T) string
+	// removed is the number of duplicates dropped by the last Build
+	removed int
+}
+
+// NewCollectionBuilder creates a CollectionBuilder building count elements,
+// each from the builder returned by newBuilder for its index.
+func NewCollectionBuilder[T any](count int, newBuilder func(i int) Builder) *CollectionBuilder[T] {
+	return &CollectionBuilder[T]{
+		count:      max(count, 0),
+		newBuilder: newBuilder,
+		workers:    1,
+	}
+}
+
+// WithWorkers builds the elements concurrently across n workers. Each element still gets its own builder,
+// so newBuilder must not return builders shared between elements. Values below 1 are treated as 1.
+func (c *CollectionBuilder[T]) WithWorkers(n int) *CollectionBuilder[T] {
+	c.workers = max(n, 1)
+	return c
+}
+
+// WithProgress registers a callback invoked after each element is built, successfully or not,
+// with the number of elements done so far and the total. Calls are serialized and done increases
+// by one on each call, even with several workers.
+func (c *CollectionBuilder[T]) WithProgress(fn func(done, total int)) *CollectionBuilder[T] {
+	c.progress = fn
+	return c
+}
+
+// WithDedup drops built elements whose key, as returned by keyFn, collides with an earlier element's,
+// keeping the first-seen order. This removes duplicates produced by random generation, e.g. by email.
+// The number of dropped elements is reported by DedupStats.
+func (c *CollectionBuilder[T]) WithDedup(keyFn func(T) string) *CollectionBuilder[T] {
+	c.dedupKey = keyFn
+	return c
+}
+
+// DedupStats returns the number of duplicates dropped by the last Build.
+func (c *CollectionBuilder[T]) DedupStats() int {
+	return c.removed
+}
+
+// Build builds every element, preserving the index order, then drops duplicates if WithDedup is set.
+// All failures are joined into the returned error, in which case no elements are returned.
+func (c *CollectionBuilder[T]) Build() ([]T, error) {
+	if c.newBuilder == nil {
+		return nil, errors.New("collection builder function cannot be nil")
+	}
+
+	elements := make([]T, c.count)
+	errs := make([]error, c.count)
+	var progressMu sync.Mutex
+	done := 0
+	runIndexed(c.count, c.workers, func(i int) {
+		elements[i], errs[i] = c.buildAt(i)
+		if c.progress != nil {
+			progressMu.Lock()
+			done++
+			c.progress(done, c.count)
+			progressMu.Unlock()
+		}
+	})
+
+	c.removed = 0
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c.dedup(elements), nil
+}
+
+// dedup removes elements with a key seen earlier, recording how many were removed.
+func (c *CollectionBuilder[T]) dedup(elements []T) []T {
+	if c.dedupKey == nil {
+		return elements
+	}
+	seen := make(map[string]bool, len(elements))
+	unique := elements[:0]
+	for _, element := range elements {
+		key := c.dedupKey(element)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, element)
+	}
+	c.removed = len(elements) - len(unique)
+	return unique
+}
+
+// buildAt builds the element at the given index, wrapping any failure with the index.
+func (c *CollectionBuilder[T]) buildAt(i int) (T, error) {
+	var zero T
+	builder := c.newBuilder(i)
+	if builder == nil {
+		return zero, fmt.Errorf("element %d: builder cannot be nil", i)
+	}
+	// Errors are checked first, as T may itself be an interface satisfied by error, e.g. any
+	switch result := builder.Build().(type) {
+	case error:
+		return zero, fmt.Errorf("element %d: %w", i, result)
+	case T:
+		return result, nil
+	default:
+		return zero, fmt.Errorf("element %d: unexpected build result %T", i, result)
+	}
+}
@@ -0,0 +1,84 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// newIndexedUser returns a builder for a valid user derived from the element index.
+func newIndexedUser(i int) Builder {
+	return NewUserBuilder().WithID(i + 1).WithName(fmt.Sprintf("User %d", i)).WithEmail(fmt.Sprintf("user%d@example.com", i))
+}
+
+func TestCollectionBuilder_Build(t *testing.T) {
+	users, err := NewCollectionBuilder[*TestUser](3, newIndexedUser).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, user := range users {
+		if user.ID != i+1 {
+			t.Errorf("Expected element %d to have ID %d, got %d", i, i+1, user.ID)
+		}
+	}
+
+	_, err = NewCollectionBuilder[*TestUser](2, func(i int) Builder {
+		if i == 1 {
+			return NewUserBuilder()
+		}
+		return newIndexedUser(i)
+	}).Build()
+	if err == nil {
+		t.Error("Expected an error for an invalid element")
+	}
+
+	// With an interface element type, build errors are still reported as errors
+	elements, err := NewCollectionBuilder[any](1, func(int) Builder { return NewUserBuilder() }).Build()
+	if err == nil || elements != nil {
+		t.Errorf("Expected an error and no elements for CollectionBuilder[any], got %v and %v", elements, err)
+	}
+}
+
+func TestCollectionBuilder_WithProgress(t *testing.T) {
+	for _, workers := range []int{1, 8} {
+		var calls [][2]int
+		_, err := NewCollectionBuilder[*TestUser](100, newIndexedUser).
+			WithWorkers(workers).
+			WithProgress(func(done, total int) { calls = append(calls, [2]int{done, total}) }).
+			Build()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(calls) != 100 || calls[len(calls)-1] != [2]int{100, 100} {
+			t.Fatalf("Expected 100 calls ending with (100, 100) for %d workers, got %d calls", workers, len(calls))
+		}
+		for i, call := range calls {
+			if call[0] != i+1 {
+				t.Errorf("Expected monotonically increasing done counts for %d workers, got %v at call %d", workers, call, i)
+			}
+		}
+	}
+}
+
+func TestCollectionBuilder_WithDedup(t *testing.T) {
+	emails := []string{"a@example.com", "b@example.com", "a@example.com", "c@example.com", "b@example.com"}
+	collection := NewCollectionBuilder[*TestUser](len(emails), func(i int) Builder {
+		return NewUserBuilder().WithID(i + 1).WithName("User").WithEmail(emails[i])
+	}).WithDedup(func(user *TestUser) string { return user.Email })
+
+	users, err := collection.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var ids []int
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	if !slices.Equal(ids, []int{1, 2, 4}) {
+		t.Errorf("Expected the first-seen users in order, got IDs %v", ids)
+	}
+	if collection.DedupStats() != 2 {
+		t.Errorf("Expected 2 removed duplicates, got %d", collection.DedupStats())
+	}
+}
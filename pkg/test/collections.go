@@ -0,0 +1,103 @@
+package testkit
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GroupMetadataKey is the metadata key used by WithGroup and GroupByGroup.
+const GroupMetadataKey = "group"
+
+// GroupBy partitions users by the key returned from keyFn, preserving input order within each group.
+func GroupBy(users []*TestUser, keyFn func(*TestUser) string) map[string][]*TestUser {
+	groups := make(map[string][]*TestUser)
+	for _, user := range users {
+		key := keyFn(user)
+		groups[key] = append(groups[key], user)
+	}
+	return groups
+}
+
+// GroupByGroup partitions users by the group set with WithGroup.
+// Users without a group are collected under the empty string key.
+func GroupByGroup(users []*TestUser) map[string][]*TestUser {
+	return GroupBy(users, func(user *TestUser) string {
+		group, _ := user.Metadata[GroupMetadataKey].(string)
+		return group
+	})
+}
+
+// SortUsers stably sorts users in place by "id", "name", "email" or "age".
+// Users with equal keys keep their relative order. Unknown keys return an error and leave users unchanged.
+func SortUsers(users []*TestUser, by string) error {
+	var compare func(a, b *TestUser) int
+	switch by {
+	case "id":
+		compare = func(a, b *TestUser) int { return cmp.Compare(a.ID, b.ID) }
+	case "name":
+		compare = func(a, b *TestUser) int { return strings.Compare(a.Name, b.Name) }
+	case "email":
+		compare = func(a, b *TestUser) int { return strings.Compare(a.Email, b.Email) }
+	case "age":
+		compare = func(a, b *TestUser) int { return cmp.Compare(a.Age, b.Age) }
+	default:
+		return fmt.Errorf("cannot sort users by unknown key '%s'", by)
+	}
+	slices.SortStableFunc(users, compare)
+	return nil
+}
+
+// SortUsersFunc stably sorts users in place using less, for orderings SortUsers doesn't support.
+func SortUsersFunc(users []*TestUser, less func(a, b *TestUser) bool) {
+	slices.SortStableFunc(users, func(a, b *TestUser) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// ValidateUsers validates each user against its `testkit` struct tags, e.g. after bulk-building from CSV.
+// It returns the errors keyed by index; valid users are omitted, so an empty map means all are valid.
+func ValidateUsers(users []*TestUser) map[int]error {
+	failures := make(map[int]error)
+	for i, user := range users {
+		if user == nil {
+			failures[i] = errors.New("user is nil")
+			continue
+		}
+		if err := Validate(user); err != nil {
+			failures[i] = err
+		}
+	}
+	return failures
+}
+
+// CombinatorialUsers returns a builder for every combination of name, age and active flag,
+// for matrix tests. The number of builders is the product of the option counts.
+func CombinatorialUsers(names []string, ages []int, actives []bool) []*UserBuilder {
+	indexes := func(n int) []int {
+		result := make([]int, n)
+		for i := range result {
+			result[i] = i
+		}
+		return result
+	}
+
+	combinations := Combinations(indexes(len(names)), indexes(len(ages)), indexes(len(actives)))
+	builders := make([]*UserBuilder, 0, len(combinations))
+	for _, combination := range combinations {
+		builders = append(builders, NewUserBuilder().
+			WithName(names[combination[0]]).
+			WithAge(ages[combination[1]]).
+			WithActive(actives[combination[2]]))
+	}
+	return builders
+}
@@ -0,0 +1,176 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func buildTestUsers(t *testing.T, builders ...*UserBuilder) []*TestUser {
+	t.Helper()
+	users := make([]*TestUser, 0, len(builders))
+	for i, builder := range builders {
+		user, ok := builder.Build().(*TestUser)
+		if !ok {
+			t.Fatalf("Builder %d: expected *TestUser", i)
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+func TestGroupByGroup(t *testing.T) {
+	newUser := func(name, group string) *UserBuilder {
+		return NewUserBuilder().WithName(name).WithEmail(name + "@example.com").WithGroup(group)
+	}
+	users := buildTestUsers(t,
+		newUser("a1", "A"),
+		newUser("b1", "B"),
+		newUser("a2", "A"),
+		newUser("b2", "B"),
+		newUser("a3", "A"),
+	)
+
+	groups := GroupByGroup(users)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if len(groups["A"]) != 3 {
+		t.Errorf("Expected 3 users in group A, got %d", len(groups["A"]))
+	}
+	if len(groups["B"]) != 2 {
+		t.Errorf("Expected 2 users in group B, got %d", len(groups["B"]))
+	}
+	if groups["A"][0].Name != "a1" || groups["A"][2].Name != "a3" {
+		t.Error("Expected input order to be preserved within a group")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	users := buildTestUsers(t,
+		NewUserBuilder().WithName("John").WithEmail("john@example.com").WithActive(true),
+		NewUserBuilder().WithName("Jane").WithEmail("jane@example.com"),
+	)
+
+	groups := GroupBy(users, func(user *TestUser) string {
+		if user.Active {
+			return "active"
+		}
+		return "inactive"
+	})
+	if len(groups["active"]) != 1 || len(groups["inactive"]) != 1 {
+		t.Errorf("Expected one user per group, got %v", groups)
+	}
+}
+
+func TestSortUsers(t *testing.T) {
+	newUsers := func() []*TestUser {
+		return []*TestUser{
+			{ID: 3, Name: "Carol", Email: "c@example.com", Age: 30},
+			{ID: 1, Name: "Alice", Email: "a@example.com", Age: 25},
+			{ID: 4, Name: "Alice", Email: "d@example.com", Age: 30},
+			{ID: 2, Name: "Bob", Email: "b@example.com", Age: 25},
+		}
+	}
+	ids := func(users []*TestUser) []int {
+		result := make([]int, 0, len(users))
+		for _, user := range users {
+			result = append(result, user.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		by       string
+		expected []int
+	}{
+		{by: "id", expected: []int{1, 2, 3, 4}},
+		{by: "name", expected: []int{1, 4, 2, 3}},
+		{by: "email", expected: []int{1, 2, 3, 4}},
+		{by: "age", expected: []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			users := newUsers()
+			if err := SortUsers(users, tt.by); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := ids(users); !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected order %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	users := newUsers()
+	if err := SortUsers(users, "nickname"); err == nil {
+		t.Error("Expected error for an unknown sort key")
+	}
+	if got := ids(users); !slices.Equal(got, []int{3, 1, 4, 2}) {
+		t.Errorf("Expected users unchanged after an error, got %v", got)
+	}
+}
+
+func TestSortUsersFunc(t *testing.T) {
+	users := []*TestUser{
+		{ID: 1, Age: 25},
+		{ID: 2, Age: 40},
+		{ID: 3, Age: 25},
+	}
+	SortUsersFunc(users, func(a, b *TestUser) bool { return a.Age > b.Age })
+
+	if users[0].ID != 2 || users[1].ID != 1 || users[2].ID != 3 {
+		t.Errorf("Expected descending age with stable ties, got %d %d %d", users[0].ID, users[1].ID, users[2].ID)
+	}
+}
+
+func TestValidateUsers(t *testing.T) {
+	users := []*TestUser{
+		{Name: "Valid", Email: "valid@example.com"},
+		{Name: "", Email: "missing-name@example.com"},
+		{Name: "Bad Email", Email: "not-an-email"},
+		nil,
+		{Name: "Also Valid", Email: "also@example.com", Age: 30},
+	}
+
+	failures := ValidateUsers(users)
+	flagged := slices.Sorted(maps.Keys(failures))
+	if !slices.Equal(flagged, []int{1, 2, 3}) {
+		t.Errorf("Expected indices 1, 2 and 3 to be flagged, got %v", flagged)
+	}
+	for index, err := range failures {
+		if err == nil {
+			t.Errorf("Expected a non-nil error at index %d", index)
+		}
+	}
+
+	if failures = ValidateUsers(users[:1]); len(failures) != 0 {
+		t.Errorf("Expected no failures for valid users, got %v", failures)
+	}
+}
+
+func TestCombinatorialUsers(t *testing.T) {
+	names := []string{"Alice", "Bob"}
+	ages := []int{18, 30, 65}
+	actives := []bool{true, false}
+
+	builders := CombinatorialUsers(names, ages, actives)
+	if len(builders) != len(names)*len(ages)*len(actives) {
+		t.Fatalf("Expected %d builders, got %d", len(names)*len(ages)*len(actives), len(builders))
+	}
+
+	seen := make(map[string]bool)
+	for _, builder := range builders {
+		user := builder.user
+		seen[fmt.Sprintf("%s/%d/%t", user.Name, user.Age, user.Active)] = true
+	}
+	for _, name := range names {
+		for _, age := range ages {
+			for _, active := range actives {
+				if key := fmt.Sprintf("%s/%d/%t", name, age, active); !seen[key] {
+					t.Errorf("Expected combination %s to be present", key)
+				}
+			}
+		}
+	}
+}
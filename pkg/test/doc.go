@@ -66,6 +66,21 @@ To create a custom builder, embed BaseBuilder and implement the Builder interfac
 		return &MyObject{Name: b.obj.Name}
 	}
 
+# Extending UserBuilder
+
+Builders embedding UserBuilder can reach the in-progress user through MutateUser:
+
+	type ContactUserBuilder struct {
+		*UserBuilder
+	}
+
+	func (b *ContactUserBuilder) WithPhoneNumber(phone string) *ContactUserBuilder {
+		b.MutateUser(func(u *TestUser) {
+			u.Metadata["phone_number"] = phone
+		})
+		return b
+	}
+
 # Configuration System
 
 Use BuilderConfig for setting up builders with defaults:
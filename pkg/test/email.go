@@ -0,0 +1,79 @@
+package testkit
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// defaultEmailLocalPart is the local part used when a name has no usable characters.
+const defaultEmailLocalPart = "user"
+
+// consumerEmailSuffixMax bounds the numeric suffix of consumer email addresses.
+const consumerEmailSuffixMax = 100
+
+// EmailProvider generates email addresses for users whose email is unset at build time.
+type EmailProvider interface {
+	// Generate returns an email address for the named user, drawing any randomness from rng.
+	Generate(name string, rng *rand.Rand) string
+}
+
+// corporateEmailProvider generates "first.last@domain" addresses.
+type corporateEmailProvider struct {
+	domain string
+}
+
+// CorporateEmailProvider returns an EmailProvider generating deterministic "first.last@domain" addresses,
+// e.g. "jane.doe@acme.test" for "Jane Doe".
+func CorporateEmailProvider(domain string) EmailProvider {
+	return corporateEmailProvider{domain: domain}
+}
+
+// Generate implements EmailProvider.
+func (p corporateEmailProvider) Generate(name string, _ *rand.Rand) string {
+	return emailLocalPart(name, ".") + "@" + p.domain
+}
+
+// consumerEmailProvider generates "firstlast42@domain" addresses on one of several domains.
+type consumerEmailProvider struct {
+	domains []string
+}
+
+// ConsumerEmailProvider returns an EmailProvider generating "firstlast42@domain" addresses,
+// with a random numeric suffix below 100 and a domain drawn from domains.
+// Without domains, "example.com", "example.net" and "example.org" are used.
+func ConsumerEmailProvider(domains ...string) EmailProvider {
+	if len(domains) == 0 {
+		domains = []string{"example.com", "example.net", "example.org"}
+	}
+	return consumerEmailProvider{domains: domains}
+}
+
+// Generate implements EmailProvider. A nil rng uses an unseeded source.
+func (p consumerEmailProvider) Generate(name string, rng *rand.Rand) string {
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec // test data, not security sensitive
+	}
+	suffix := strconv.Itoa(rng.IntN(consumerEmailSuffixMax))
+	return emailLocalPart(name, "") + suffix + "@" + p.domains[rng.IntN(len(p.domains))]
+}
+
+// emailLocalPart lowercases the words of name, keeping only ASCII letters and digits, and joins them with sep.
+func emailLocalPart(name, sep string) string {
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(name)) {
+		word = strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return defaultEmailLocalPart
+	}
+	return strings.Join(words, sep)
+}
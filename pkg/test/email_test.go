@@ -0,0 +1,77 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"testing"
+)
+
+func TestCorporateEmailProvider(t *testing.T) {
+	provider := CorporateEmailProvider("acme.test")
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Jane Doe", expected: "jane.doe@acme.test"},
+		{name: "  Mary  Ann O'Neil ", expected: "mary.ann.oneil@acme.test"},
+		{name: "", expected: "user@acme.test"},
+	}
+
+	for _, tt := range tests {
+		if got := provider.Generate(tt.name, nil); got != tt.expected {
+			t.Errorf("Generate(%q): expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestConsumerEmailProvider(t *testing.T) {
+	newRng := func() *rand.Rand { return rand.New(rand.NewPCG(3, 3)) } //nolint:gosec // deterministic test data
+
+	provider := ConsumerEmailProvider("mail.test", "inbox.test")
+	format := regexp.MustCompile(`^janedoe\d{1,2}@(mail|inbox)\.test$`)
+	rng := newRng()
+	for range 20 {
+		if email := provider.Generate("Jane Doe", rng); !format.MatchString(email) {
+			t.Errorf("Unexpected consumer email %q", email)
+		}
+	}
+
+	if provider.Generate("Jane Doe", newRng()) != provider.Generate("Jane Doe", newRng()) {
+		t.Error("Expected the same seed to generate the same email")
+	}
+
+	defaults := regexp.MustCompile(`^janedoe\d{1,2}@example\.(com|net|org)$`)
+	if email := ConsumerEmailProvider().Generate("Jane Doe", nil); !defaults.MatchString(email) {
+		t.Errorf("Expected a default example domain, got %q", email)
+	}
+}
+
+func TestUserBuilder_WithEmailProvider(t *testing.T) {
+	user := NewUserBuilder().WithName("Jane Doe").WithEmailProvider(CorporateEmailProvider("acme.test")).BuildT(t)
+	if user.Email != "jane.doe@acme.test" {
+		t.Errorf("Expected a generated email, got %q", user.Email)
+	}
+
+	explicit := NewUserBuilder().
+		WithName("Jane Doe").
+		WithEmail("jane@example.com").
+		WithEmailProvider(CorporateEmailProvider("acme.test")).
+		BuildT(t)
+	if explicit.Email != "jane@example.com" {
+		t.Errorf("Expected an explicit email to take precedence, got %q", explicit.Email)
+	}
+
+	build := func() string {
+		builder := NewUserBuilder().WithName("Jane Doe").WithEmailProvider(ConsumerEmailProvider())
+		builder.Seed(11)
+		return builder.BuildT(t).Email
+	}
+	if build() != build() {
+		t.Error("Expected seeded builders to generate the same email")
+	}
+
+	if report := NewUserBuilder().WithName("Jane").WithEmailProvider(ConsumerEmailProvider()).ValidationReport(); !report.Valid {
+		t.Errorf("Expected the provider to satisfy email validation, got %v", report.FieldErrors)
+	}
+}
@@ -0,0 +1,204 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+)
+
+// EntityBuilder is a reflection-based builder for any struct type T.
+// Fields are set by name with WithField and assembled into a new *T at build time.
+type EntityBuilder[T any] struct {
+	*BaseBuilder
+
+	fields map[string]any
+}
+
+// NewEntityBuilder creates a new EntityBuilder for the struct type T.
+func NewEntityBuilder[T any]() *EntityBuilder[T] {
+	return &EntityBuilder[T]{
+		BaseBuilder: NewBaseBuilder(),
+		fields:      make(map[string]any),
+	}
+}
+
+// RegisterEntity registers an EntityBuilder for the struct type T in the default factory.
+func RegisterEntity[T any](name string) error {
+	if reflect.TypeFor[T]().Kind() != reflect.Struct {
+		return fmt.Errorf("entity type %s must be a struct", reflect.TypeFor[T]())
+	}
+	return RegisterBuilder(name, func() Builder {
+		return NewEntityBuilder[T]()
+	})
+}
+
+// WithField sets an exported field of the entity by name.
+// Unknown fields, fields promoted through an embedded pointer, and values of incompatible types
+// are recorded as errors.
+func (b *EntityBuilder[T]) WithField(name string, value any) *EntityBuilder[T] {
+	if !b.Mutable() {
+		return b
+	}
+	field, exists := reflect.TypeFor[T]().FieldByName(name)
+	if !exists || !field.IsExported() {
+		b.AddError(fmt.Errorf("entity %s has no exported field '%s'", reflect.TypeFor[T](), name))
+		return b
+	}
+	if promotedThroughPointer(reflect.TypeFor[T](), field.Index) {
+		b.AddError(fmt.Errorf("entity %s field '%s' is promoted through an embedded pointer", reflect.TypeFor[T](), name))
+		return b
+	}
+	if _, err := convertFieldValue(value, field.Type); err != nil {
+		b.AddError(fmt.Errorf("field '%s': %w", name, err))
+		return b
+	}
+	b.fields[name] = value
+	return b
+}
+
+// Build creates a new *T with the configured fields.
+func (b *EntityBuilder[T]) Build() any {
+	defer b.EnterGate()()
+	if b.HasErrors() {
+		return fmt.Errorf("cannot build entity due to validation errors: %w", errors.Join(b.GetErrors()...))
+	}
+	if b.IsValidationEnabled() {
+		if err := b.RunValidators(b); err != nil {
+			return err
+		}
+	}
+
+	entity := new(T)
+	target := reflect.ValueOf(entity).Elem()
+	for name, value := range b.fields {
+		structField, _ := target.Type().FieldByName(name)
+		field, err := target.FieldByIndexErr(structField.Index)
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", name, err)
+		}
+		converted, err := convertFieldValue(value, field.Type())
+		if err != nil {
+			return fmt.Errorf("field '%s': %w", name, err)
+		}
+		field.Set(converted)
+	}
+	b.RecordBuild()
+	return entity
+}
+
+// Reset clears the builder state for reuse.
+func (b *EntityBuilder[T]) Reset() Builder {
+	b.BaseBuilder.Reset()
+	b.fields = make(map[string]any)
+	return b
+}
+
+// Clone creates a copy of the EntityBuilder. Field values are copied shallowly.
+func (b *EntityBuilder[T]) Clone() Builder {
+	baseClone, _ := b.BaseBuilder.Clone().(*BaseBuilder)
+	return &EntityBuilder[T]{
+		BaseBuilder: baseClone,
+		fields:      maps.Clone(b.fields),
+	}
+}
+
+// CloneConfig returns a fresh EntityBuilder sharing the BaseBuilder configuration of b, without any field values.
+func (b *EntityBuilder[T]) CloneConfig() Builder {
+	clone := NewEntityBuilder[T]()
+	clone.BaseBuilder = b.cloneConfig()
+	return clone
+}
+
+// OutputType implements TypedBuilder.
+func (b *EntityBuilder[T]) OutputType() reflect.Type {
+	return reflect.TypeFor[*T]()
+}
+
+// promotedThroughPointer reports whether the field at index is reached through an embedded pointer,
+// which is nil in a new entity.
+func promotedThroughPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// convertFieldValue converts a value to the field type.
+// Nil becomes the zero value; numeric values convert between numeric kinds.
+func convertFieldValue(value any, fieldType reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(fieldType), nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(fieldType) {
+		return v, nil
+	}
+	if isNumericKind(v.Kind()) && isNumericKind(fieldType.Kind()) {
+		if err := checkNumericFit(v, fieldType); err != nil {
+			return reflect.Value{}, err
+		}
+		return v.Convert(fieldType), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot assign %s to %s", v.Type(), fieldType)
+}
+
+// checkNumericFit reports an error when the numeric value v cannot be converted to fieldType without
+// overflowing it, wrapping a negative value around an unsigned type, or truncating a fraction.
+func checkNumericFit(v reflect.Value, fieldType reflect.Type) error {
+	target := reflect.New(fieldType).Elem()
+	overflows := false
+	switch {
+	case v.CanInt():
+		n := v.Int()
+		switch {
+		case target.CanInt():
+			overflows = target.OverflowInt(n)
+		case target.CanUint():
+			overflows = n < 0 || target.OverflowUint(uint64(n))
+		}
+	case v.CanUint():
+		n := v.Uint()
+		switch {
+		case target.CanInt():
+			overflows = n > math.MaxInt64 || target.OverflowInt(int64(n))
+		case target.CanUint():
+			overflows = target.OverflowUint(n)
+		}
+	default:
+		f := v.Float()
+		if target.CanFloat() {
+			overflows = target.OverflowFloat(f)
+			break
+		}
+		if f != math.Trunc(f) {
+			return fmt.Errorf("cannot assign fractional %v to %s", f, fieldType)
+		}
+		switch {
+		case target.CanInt():
+			overflows = f < math.MinInt64 || f >= math.MaxInt64 || target.OverflowInt(int64(f))
+		case target.CanUint():
+			overflows = f < 0 || f >= math.MaxUint64 || target.OverflowUint(uint64(f))
+		}
+	}
+	if overflows {
+		return fmt.Errorf("value %v overflows %s", v, fieldType)
+	}
+	return nil
+}
+
+// isNumericKind reports whether a kind is an integer or floating point kind.
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind { //nolint:exhaustive // only numeric kinds are relevant
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,165 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"testing"
+)
+
+type testProduct struct {
+	Name  string
+	Price float64
+	Stock int64
+	Tags  []string
+}
+
+func TestRegisterEntity(t *testing.T) {
+	original := DefaultFactory
+	DefaultFactory = NewBuilderFactory()
+	defer func() { DefaultFactory = original }()
+
+	if err := RegisterEntity[testProduct]("product"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := RegisterEntity[int]("number"); err == nil {
+		t.Error("Expected error for non-struct entity type")
+	}
+
+	builder, err := CreateBuilder("product")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	productBuilder, ok := builder.(*EntityBuilder[testProduct])
+	if !ok {
+		t.Fatalf("Expected *EntityBuilder[testProduct], got %T", builder)
+	}
+
+	productBuilder.
+		WithField("Name", "Widget").
+		WithField("Price", 9.99).
+		WithField("Stock", 3).
+		WithField("Tags", []string{"sale"})
+
+	product, ok := productBuilder.Build().(*testProduct)
+	if !ok {
+		t.Fatalf("Expected *testProduct, got %v", productBuilder.Build())
+	}
+	if product.Name != "Widget" || product.Price != 9.99 || product.Stock != 3 || len(product.Tags) != 1 {
+		t.Errorf("Expected fields to be assembled, got %+v", product)
+	}
+}
+
+func TestEntityBuilder_Errors(t *testing.T) {
+	builder := NewEntityBuilder[testProduct]()
+	builder.WithField("Missing", "value")
+	builder.WithField("Name", 42)
+
+	if len(builder.GetErrors()) != 2 {
+		t.Errorf("Expected 2 errors, got %v", builder.GetErrors())
+	}
+	if _, isError := builder.Build().(error); !isError {
+		t.Error("Expected build to fail")
+	}
+}
+
+type testCounters struct {
+	Small int8
+	Count uint
+	Whole int
+	Ratio float32
+}
+
+func TestEntityBuilder_NumericConversion(t *testing.T) {
+	tests := []struct {
+		field string
+		value any
+		valid bool
+	}{
+		{field: "Small", value: 100, valid: true},
+		{field: "Small", value: 300},
+		{field: "Small", value: uint64(128)},
+		{field: "Count", value: 7, valid: true},
+		{field: "Count", value: -1},
+		{field: "Count", value: -1.0},
+		{field: "Whole", value: 4.0, valid: true},
+		{field: "Whole", value: 3.9},
+		{field: "Whole", value: 1e30},
+		{field: "Ratio", value: 0.5, valid: true},
+		{field: "Ratio", value: 1e300},
+	}
+	for _, tt := range tests {
+		builder := NewEntityBuilder[testCounters]().WithField(tt.field, tt.value)
+		if builder.HasErrors() == tt.valid {
+			t.Errorf("Expected %v into %s to be valid=%v, got errors %v", tt.value, tt.field, tt.valid, builder.GetErrors())
+		}
+	}
+
+	counters, ok := NewEntityBuilder[testCounters]().WithField("Small", -128).WithField("Whole", 4.0).Build().(*testCounters)
+	if !ok || counters.Small != -128 || counters.Whole != 4 {
+		t.Errorf("Expected in-range values to convert exactly, got %+v", counters)
+	}
+}
+
+type testAudit struct {
+	CreatedBy string
+}
+
+type testRevision struct {
+	Number int
+}
+
+type testDocument struct {
+	*testAudit
+	testRevision
+
+	Title string
+}
+
+func TestEntityBuilder_EmbeddedFields(t *testing.T) {
+	builder := NewEntityBuilder[testDocument]().WithField("Title", "Spec").WithField("Number", 2)
+	document, ok := builder.Build().(*testDocument)
+	if !ok || document.Number != 2 || document.Title != "Spec" {
+		t.Errorf("Expected fields promoted through an embedded struct to be set, got %+v", document)
+	}
+
+	if !NewEntityBuilder[testDocument]().WithField("CreatedBy", "jane").HasErrors() {
+		t.Error("Expected an error for a field promoted through an embedded pointer")
+	}
+
+	// Build reports such fields as errors instead of panicking
+	builder.fields["CreatedBy"] = "jane"
+	if _, isError := builder.Build().(error); !isError {
+		t.Error("Expected build to fail for a field behind a nil embedded pointer")
+	}
+}
+
+func TestEntityBuilder_CloneAndReset(t *testing.T) {
+	builder := NewEntityBuilder[testProduct]().WithField("Name", "Widget")
+
+	clone, ok := builder.Clone().(*EntityBuilder[testProduct])
+	if !ok {
+		t.Fatal("Expected EntityBuilder clone")
+	}
+	clone.WithField("Name", "Gadget")
+	product, _ := builder.Build().(*testProduct)
+	if product == nil || product.Name != "Widget" {
+		t.Error("Modifying the clone should not affect the original")
+	}
+
+	builder.Reset()
+	product, _ = builder.Build().(*testProduct)
+	if product == nil || product.Name != "" {
+		t.Error("Expected reset to clear fields")
+	}
+}
+
+func TestEntityBuilder_CloneConfig(t *testing.T) {
+	builder := NewEntityBuilder[testProduct]().WithField("Name", "Widget")
+	builder.WithTag("kind", "sample")
+
+	clone, ok := builder.CloneConfig().(*EntityBuilder[testProduct])
+	if !ok {
+		t.Fatal("Expected CloneConfig to return an *EntityBuilder")
+	}
+	if clone.GetTag("kind") != "sample" || len(clone.fields) != 0 {
+		t.Errorf("Expected tags without fields, got tags %v and fields %v", clone.GetTags(), clone.fields)
+	}
+}
@@ -0,0 +1,118 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCyclicReference is returned when builders reference each other in a cycle.
+var ErrCyclicReference = errors.New("cyclic builder reference detected")
+
+// errorHints maps the package's sentinel errors to remediation hints, used by ExplainErrors.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var errorHints = map[error]string{
+	ErrNameRequired:      "call WithName with a non-empty name",
+	ErrEmailRequired:     "call WithEmail with a non-empty address",
+	ErrNegativeAge:       "call WithAge with zero or a positive age, or WithClampedAge to clamp it into range",
+	ErrNegativeID:        "call WithID with zero or a positive ID",
+	ErrBuilderFrozen:     "call Clone to get a mutable copy instead of changing a frozen builder",
+	ErrBuilderConsumed:   "call Reset before configuring a built builder again, or configure a Clone",
+	ErrCyclicReference:   "remove one of the builder references forming the cycle",
+	ErrBuildTimeout:      "increase the timeout or remove the simulated latency of the builder",
+	ErrSequenceExhausted: "create the jittered sequence with a larger count",
+	ErrValidationTimeout: "speed up the slow validator or raise the limit set with WithValidationTimeout",
+}
+
+// FieldError describes a validation failure of a single field.
+// Metadata fields are named with a "metadata." prefix, e.g. "metadata.created_at".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// sentinelError carries a formatted message while unwrapping to a sentinel error,
+// so errors.Is matches the sentinel without changing the message text.
+type sentinelError struct {
+	message  string
+	sentinel error
+}
+
+// newSentinelError creates an error with the given message that unwraps to sentinel.
+func newSentinelError(message string, sentinel error) error {
+	return &sentinelError{message: message, sentinel: sentinel}
+}
+
+// Error implements the error interface.
+func (e *sentinelError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the sentinel error.
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
+}
+
+// fieldErrorsOf flattens an error, possibly joined with errors.Join, into field errors.
+// Sentinel errors are attributed to their field; other errors get an empty Field.
+func fieldErrorsOf(err error) []*FieldError {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // only the top level is flattened
+		var result []*FieldError
+		for _, inner := range joined.Unwrap() {
+			result = append(result, fieldErrorsOf(inner)...)
+		}
+		return result
+	}
+
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return []*FieldError{fieldErr}
+	}
+	return []*FieldError{{Field: sentinelField(err), Message: err.Error()}}
+}
+
+// sentinelField returns the user field a sentinel error describes, or an empty string.
+func sentinelField(err error) string {
+	switch {
+	case errors.Is(err, ErrNameRequired):
+		return "name"
+	case errors.Is(err, ErrEmailRequired):
+		return "email"
+	case errors.Is(err, ErrNegativeAge):
+		return "age"
+	case errors.Is(err, ErrNegativeID):
+		return "id"
+	default:
+		return ""
+	}
+}
+
+// explainErrors returns a remediation hint, prefixed with the error message, for each error known to errorHints.
+// Errors joined with errors.Join are explained individually; unknown errors are skipped.
+func explainErrors(err error) []string {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint // only the top level is flattened
+		var result []string
+		for _, inner := range joined.Unwrap() {
+			result = append(result, explainErrors(inner)...)
+		}
+		return result
+	}
+
+	for sentinel, hint := range errorHints {
+		if errors.Is(err, sentinel) {
+			return []string{fmt.Sprintf("%s: %s", err.Error(), hint)}
+		}
+	}
+	return nil
+}
@@ -1,20 +1,105 @@
 package testkit
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"maps"
+	"math"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	randomMinAge = 18
+	randomMaxAge = 80
+	randomMaxID  = 1_000_000
+
+	// BirthdateMetadataKey is the metadata key used by WithBirthdate.
+	BirthdateMetadataKey = "birthdate"
+	// DeadlineMetadataKey is the metadata key used by WithDeadline and IsExpired.
+	DeadlineMetadataKey = "deadline"
+	// CreatedAtMetadataKey is the metadata key used by WithCreatedAt.
+	CreatedAtMetadataKey = "created_at"
+
+	// CompositeKeyMetadataKey is the metadata key WithCompositeKey stores the computed key under.
+	CompositeKeyMetadataKey = "composite_key"
+	// compositeKeySeparator joins the field values of a composite key
+	compositeKeySeparator = "|"
+
+	// TagsMetadataKey is the metadata key MirrorTagsToMetadata stores the builder tags under.
+	TagsMetadataKey = "tags"
+
+	// minimalUserName and minimalUserEmail are the placeholders set by WithMinimalUser
+	minimalUserName  = "User"
+	minimalUserEmail = "user@example.test"
+
+	// maxRepairPasses bounds how many times repairs and validation are re-run during Build
+	maxRepairPasses = 3
+)
+
+//nolint:gochecknoglobals // fixed pools used for random user generation
+var (
+	randomizableFields = []string{"id", "name", "email", "age", "active"}
+
+	randomFirstNames = []string{"Alice", "Bob", "Carol", "David", "Eve", "Frank", "Grace", "Heidi"}
+	randomLastNames  = []string{"Smith", "Johnson", "Brown", "Taylor", "Wilson", "Clark", "Lewis", "Walker"}
+)
+
+// Sentinel errors reported by UserBuilder validation, usable with errors.Is.
+var (
+	ErrNameRequired  = errors.New("user name is required")
+	ErrEmailRequired = errors.New("user email is required")
+	ErrNegativeAge   = errors.New("user age must be non-negative")
+	ErrNegativeID    = errors.New("user ID must be non-negative")
 )
 
 // TestUser represents a test user entity for demonstration purposes.
 type TestUser struct {
-	ID       int
-	Name     string
-	Email    string
-	Age      int
-	Active   bool
+	ID       int    `testkit:"min=0"`
+	Name     string `testkit:"required,default=unknown"`
+	Email    string `testkit:"required,email,default=unknown@example.com"`
+	Age      int    `testkit:"min=0"`
+	Active   bool   `testkit:"default=true"`
 	Tags     map[string]string
 	Metadata map[string]any
+	// SchemaVersion is the version of the TestUser shape, used by MigrateUser to upgrade old fixtures
+	SchemaVersion int
+}
+
+// valuePool is a list of values picked at random at build time.
+type valuePool struct {
+	values []string
+	rng    *rand.Rand
+}
+
+// pick returns a random value of the pool, drawn from the pool's rng or else from the fallback source.
+func (p *valuePool) pick(fallback func() *rand.Rand) string {
+	rng := p.rng
+	if rng == nil {
+		rng = fallback()
+	}
+	return p.values[rng.IntN(len(p.values))]
+}
+
+// lazyMetadataValue is a metadata value computed at build time by WithLazyMetadata.
+type lazyMetadataValue struct {
+	key string
+	fn  func(u *TestUser) any
+}
+
+// userSnapshot captures the user state of a UserBuilder.
+type userSnapshot struct {
+	user      *TestUser
+	setFields map[string]bool
 }
 
 // UserBuilder builds TestUser instances for testing.
@@ -22,7 +107,71 @@ type TestUser struct {
 type UserBuilder struct {
 	*BaseBuilder
 
-	user *TestUser
+	user             *TestUser
+	structValidation bool
+	// contentDerivedID computes the ID from the name and email at build time
+	contentDerivedID bool
+	// ageOptional treats an age that was never set as absent rather than zero
+	ageOptional bool
+	// maxMetadataBytes limits the JSON-encoded metadata size when positive
+	maxMetadataBytes int
+	// maxNameLength limits the name length in runes when positive
+	maxNameLength int
+	// strictNameLength rejects names over maxNameLength instead of truncating them
+	strictNameLength bool
+	// setFields tracks which user fields were explicitly set
+	setFields map[string]bool
+	// rng is the random source used by Randomize, set by Seed
+	rng *rand.Rand
+	// seed is the seed rng was created from, from which clone seeds are derived
+	seed int64
+	// cloneCount numbers the clones of a seeded builder, so each derives a distinct seed
+	cloneCount int
+	// randomFields tracks the fields holding values generated by Randomize
+	randomFields map[string]bool
+	// emailSource provides the email lazily at build time
+	emailSource *RoundRobin[string]
+	// emailProvider generates the email at build time when it is unset
+	emailProvider EmailProvider
+	// namePool and emailPool provide the name and email, picked at build time
+	namePool  *valuePool
+	emailPool *valuePool
+	// repairs attempt to fix validation failures at build time
+	repairs []func(*TestUser) bool
+	// metadataSchema maps required metadata keys to their expected kinds
+	metadataSchema map[string]reflect.Kind
+	// exclusiveFields lists groups of fields of which at most one may be set
+	exclusiveFields [][]string
+	// userRefs maps metadata keys to builders whose built ID is stored under that key
+	userRefs map[string]*UserBuilder
+	// children maps metadata keys to builders whose built objects are stored as a slice under that key
+	children map[string][]Builder
+	// template holds the user snapshot restored by ResetToTemplate
+	template *userSnapshot
+	// metadataNamespace prefixes keys passed to WithMetadata when set
+	metadataNamespace string
+	// detectConflicts reports metadata writes overwriting a different value
+	detectConflicts bool
+	// strictConflicts reports metadata conflicts as errors instead of warnings
+	strictConflicts bool
+	// lazyMetadata computes metadata values from the assembled user at build time
+	lazyMetadata []lazyMetadataValue
+	// lockedMetadataKeys holds metadata keys that further writes leave unchanged
+	lockedMetadataKeys map[string]bool
+	// maskedEmailKey stores the masked email in metadata at build time when set
+	maskedEmailKey string
+	// mirrorTags copies the builder tags into the user metadata at build time
+	mirrorTags bool
+	// canonicalEmailKey stores the canonical email in metadata at build time when set
+	canonicalEmailKey string
+	// compositeKeyFields lists the fields joined into the composite key at build time
+	compositeKeyFields []string
+	// building is set while Build runs, to detect cyclic references
+	building bool
+	// validating is the assembled user checked by validation profile rules while they run
+	validating *TestUser
+	// memoized holds the user returned by BuildMemoized until the builder is mutated or reset
+	memoized *TestUser
 }
 
 // NewUserBuilder creates a new UserBuilder instance.
@@ -30,144 +179,1438 @@ func NewUserBuilder() *UserBuilder {
 	return &UserBuilder{
 		BaseBuilder: NewBaseBuilder(),
 		user: &TestUser{
-			Tags:     make(map[string]string),
-			Metadata: make(map[string]any),
+			Tags:          make(map[string]string),
+			Metadata:      make(map[string]any),
+			SchemaVersion: CurrentSchemaVersion,
 		},
+		setFields: make(map[string]bool),
+	}
+}
+
+// ToBuilder returns a fresh UserBuilder pre-populated from the user, with all fields marked as set.
+// It is the inverse of Build, useful to load a known user, change one field and rebuild.
+func (u *TestUser) ToBuilder() *UserBuilder {
+	builder := NewUserBuilder()
+	builder.user = copyUser(u)
+	for _, field := range []string{"id", "name", "email", "age", "active"} {
+		builder.markSet(field)
 	}
+	return builder
+}
+
+// IsFieldSet reports whether a user field ("id", "name", "email", "age" or "active") was explicitly set.
+func (b *UserBuilder) IsFieldSet(field string) bool {
+	return b.setFields[field]
+}
+
+// markSet records that a user field was explicitly set, and so is no longer randomly generated.
+func (b *UserBuilder) markSet(field string) {
+	if b.setFields == nil {
+		b.setFields = make(map[string]bool)
+	}
+	b.setFields[field] = true
+	delete(b.randomFields, field)
 }
 
 // WithID sets the user ID.
 func (b *UserBuilder) WithID(id int) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.IsValidationEnabled() && id < 0 {
-		b.AddError(errors.New("user ID must be non-negative"))
+		b.AddError(newSentinelError(b.formatError("id", "non_negative", id, ErrNegativeID.Error()), ErrNegativeID))
 		return b
 	}
 	b.user.ID = id
+	b.markSet("id")
+	b.recordAudit("WithID", "id", id)
 	return b
 }
 
+// WithIDFrom sets the user ID to the next value of seq.
+// An exhausted jittered sequence is recorded as an error wrapping ErrSequenceExhausted, failing the build.
+func (b *UserBuilder) WithIDFrom(seq *Sequence) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	id, err := seq.TryNext()
+	if err != nil {
+		b.AddError(fmt.Errorf("cannot take user ID: %w", err))
+		return b
+	}
+	return b.WithID(id)
+}
+
 // WithName sets the user name.
 func (b *UserBuilder) WithName(name string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.IsValidationEnabled() && name == "" {
-		b.AddError(errors.New("user name cannot be empty"))
+		b.AddError(newSentinelError(b.formatError("name", "required", name, "user name cannot be empty"), ErrNameRequired))
 		return b
 	}
+	if runes := []rune(name); b.maxNameLength > 0 && len(runes) > b.maxNameLength {
+		if b.strictNameLength {
+			b.AddError(errors.New(b.formatError("name", "max_length", name,
+				fmt.Sprintf("user name exceeds the maximum length of %d", b.maxNameLength))))
+			return b
+		}
+		name = string(runes[:b.maxNameLength])
+		b.AddWarning(fmt.Errorf("user name truncated to %d characters", b.maxNameLength))
+	}
 	b.user.Name = name
+	b.markSet("name")
+	b.recordAudit("WithName", "name", name)
+	return b
+}
+
+// WithResolvedName sets the user name from a dependency injection container at call time.
+// A missing key, or a value that is not a string, adds a validation error.
+func (b *UserBuilder) WithResolvedName(container Container, key string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if container == nil {
+		b.AddError(errors.New("container cannot be nil"))
+		return b
+	}
+	value, exists := container.Resolve(key)
+	if !exists {
+		b.AddError(&FieldError{Field: "name", Message: fmt.Sprintf("container key '%s' not found", key)})
+		return b
+	}
+	name, ok := value.(string)
+	if !ok {
+		b.AddError(&FieldError{Field: "name", Message: fmt.Sprintf("container key '%s' holds %T, not a string", key, value)})
+		return b
+	}
+	return b.WithName(name)
+}
+
+// WithContentDerivedID makes Build compute the ID from a hash of the name and email, so the same logical user
+// gets the same ID across runs, which suits idempotent fixtures. It overrides any ID set with WithID.
+// Distinct users can collide, with a probability growing with the number of users;
+// use a Sequence instead when IDs must be unique.
+func (b *UserBuilder) WithContentDerivedID() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.contentDerivedID = true
+	return b
+}
+
+// contentID hashes the name and email into the positive int32 range.
+func contentID(name, email string) int {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(email))
+	return int(hash.Sum64()%math.MaxInt32) + 1 //nolint:gosec // the modulo keeps the value in range
+}
+
+// WithMaxNameLength limits names set with WithName to n characters, counted in runes.
+// Longer names are truncated with a warning, or rejected with an error when strict is true.
+// A non-positive n removes the limit.
+func (b *UserBuilder) WithMaxNameLength(n int, strict bool) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.maxNameLength = n
+	b.strictNameLength = strict
 	return b
 }
 
 // WithEmail sets the user email.
 func (b *UserBuilder) WithEmail(email string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.IsValidationEnabled() && email == "" {
-		b.AddError(errors.New("user email cannot be empty"))
+		b.AddError(newSentinelError(b.formatError("email", "required", email, "user email cannot be empty"), ErrEmailRequired))
 		return b
 	}
 	b.user.Email = email
+	b.markSet("email")
+	b.recordAudit("WithEmail", "email", email)
 	return b
 }
 
 // WithAge sets the user age.
 func (b *UserBuilder) WithAge(age int) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.IsValidationEnabled() && age < 0 {
-		b.AddError(errors.New("user age must be non-negative"))
+		b.AddError(newSentinelError(b.formatError("age", "non_negative", age, ErrNegativeAge.Error()), ErrNegativeAge))
 		return b
 	}
 	b.user.Age = age
+	b.markSet("age")
+	b.recordAudit("WithAge", "age", age)
 	return b
 }
 
+// WithClampedAge sets the user age, clamping it into the [minAge, maxAge] range.
+// Out-of-range ages are corrected and recorded as a warning instead of an error.
+func (b *UserBuilder) WithClampedAge(age, minAge, maxAge int) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if minAge > maxAge {
+		b.AddError(fmt.Errorf("invalid age range: min %d is greater than max %d", minAge, maxAge))
+		return b
+	}
+	clamped := max(minAge, min(age, maxAge))
+	if clamped != age {
+		b.AddWarning(fmt.Errorf("user age %d clamped to %d", age, clamped))
+	}
+	return b.WithAge(clamped)
+}
+
+// WithBirthdate sets the user age computed from the birthdate relative to the clock's current time,
+// and stores the birthdate in metadata under BirthdateMetadataKey.
+// The age is computed at call time; call WithBirthdate again after advancing the clock to recompute it.
+// A nil clock uses the global clock, see SetGlobalClock.
+func (b *UserBuilder) WithBirthdate(birthdate time.Time, clock Clock) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	clock = clockOrGlobal(clock)
+	now := clock.Now()
+	if b.IsValidationEnabled() && birthdate.After(now) {
+		b.AddError(fmt.Errorf("user birthdate %s is in the future", birthdate.Format(time.DateOnly)))
+		return b
+	}
+	b.WithMetadata(BirthdateMetadataKey, birthdate)
+	return b.WithAge(ageAt(birthdate, now))
+}
+
+// WithDeadline stores a deadline in metadata under DeadlineMetadataKey, after which the user
+// is considered expired by TestUser.IsExpired, e.g. to model session or token expiry.
+// A deadline already passed according to the clock is recorded as a warning.
+// A nil clock uses the global clock, see SetGlobalClock. The key is never namespaced, so IsExpired always finds it,
+// but locks and the audit trail apply as with WithMetadata.
+func (b *UserBuilder) WithDeadline(deadline time.Time, clock Clock) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	clock = clockOrGlobal(clock)
+	if !clock.Now().Before(deadline) {
+		b.AddWarning(fmt.Errorf("user deadline %s has already passed", deadline.Format(time.RFC3339)))
+	}
+	return b.writeMetadata("WithDeadline", DeadlineMetadataKey, deadline)
+}
+
+// WithCreatedAt stores the clock's current time in metadata under CreatedAtMetadataKey.
+// A nil clock uses the global clock, see SetGlobalClock.
+func (b *UserBuilder) WithCreatedAt(clock Clock) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.WithMetadata(CreatedAtMetadataKey, clockOrGlobal(clock).Now())
+	return b
+}
+
+// ageAt returns the age in full years of someone born at birthdate, at the given time.
+func ageAt(birthdate, now time.Time) int {
+	age := now.Year() - birthdate.Year()
+	if !sameOrLaterMonthDay(now, birthdate) {
+		age--
+	}
+	return age
+}
+
+// sameOrLaterMonthDay reports whether a's month and day are on or after b's.
+func sameOrLaterMonthDay(a, b time.Time) bool {
+	if a.Month() != b.Month() {
+		return a.Month() > b.Month()
+	}
+	return a.Day() >= b.Day()
+}
+
 // WithActive sets the user active status.
 func (b *UserBuilder) WithActive(active bool) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	b.user.Active = active
+	b.markSet("active")
+	b.recordAudit("WithActive", "active", active)
 	return b
 }
 
 // WithUserTag adds a tag specific to the user entity.
 func (b *UserBuilder) WithUserTag(key, value string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
 	if b.user.Tags == nil {
 		b.user.Tags = make(map[string]string)
 	}
 	b.user.Tags[key] = value
+	b.recordAudit("WithUserTag", key, value)
 	return b
 }
 
 // WithMetadata adds metadata to the user.
+// If a namespace was set with WithMetadataNamespace, the key is stored as "namespace.key".
 func (b *UserBuilder) WithMetadata(key string, value any) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	return b.writeMetadata("WithMetadata", b.namespacedKey(key), value)
+}
+
+// writeMetadata sets a metadata key as is, honouring locks and conflict detection,
+// and records the write in the audit trail under method.
+func (b *UserBuilder) writeMetadata(method, key string, value any) *UserBuilder {
+	if b.setMetadata(key, value) {
+		b.recordAudit(method, key, value)
+	}
+	return b
+}
+
+// WithMetadataNamespace prefixes the keys of subsequent WithMetadata calls with "ns.".
+// An empty namespace restores unprefixed keys.
+func (b *UserBuilder) WithMetadataNamespace(ns string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.metadataNamespace = ns
+	return b
+}
+
+// namespacedKey returns the metadata key prefixed with the current namespace, if any.
+func (b *UserBuilder) namespacedKey(key string) string {
+	if b.metadataNamespace == "" {
+		return key
+	}
+	return b.metadataNamespace + "." + key
+}
+
+// LockMetadataKey makes the metadata key read-only, so a value set by a preset isn't clobbered by a test.
+// Later writes to the key are ignored with a warning. The key is matched exactly, ignoring any namespace.
+func (b *UserBuilder) LockMetadataKey(key string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if b.lockedMetadataKeys == nil {
+		b.lockedMetadataKeys = make(map[string]bool)
+	}
+	b.lockedMetadataKeys[key] = true
+	return b
+}
+
+// DetectMetadataConflicts reports metadata writes overwriting an existing key with a different value,
+// to catch accidental clobbering in layered fixtures. Conflicts are recorded as warnings,
+// or as errors failing the build when strict is true. The new value is stored in both cases.
+func (b *UserBuilder) DetectMetadataConflicts(strict bool) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.detectConflicts = true
+	b.strictConflicts = strict
+	return b
+}
+
+// setMetadata stores a metadata value under the exact key given, unless the key is locked.
+// It reports whether the value was stored.
+func (b *UserBuilder) setMetadata(key string, value any) bool {
+	if b.lockedMetadataKeys[key] {
+		b.AddWarning(fmt.Errorf("metadata key '%s' is locked, ignoring write", key))
+		return false
+	}
+	if old, exists := b.user.Metadata[key]; b.detectConflicts && exists && !reflect.DeepEqual(old, value) {
+		conflict := &FieldError{
+			Field:   "metadata." + key,
+			Message: fmt.Sprintf("conflicting write overwrites %v with %v", old, value),
+		}
+		if b.strictConflicts {
+			b.AddError(conflict)
+		} else {
+			b.AddWarning(conflict)
+		}
+	}
 	if b.user.Metadata == nil {
 		b.user.Metadata = make(map[string]any)
 	}
 	b.user.Metadata[key] = value
+	return true
+}
+
+// WithLazyMetadata stores the value computed by fn under key at build time, for values depending on fields
+// finalized late. fn receives the assembled user after all other fields, including lazily evaluated ones,
+// are set, and before validation. Lazy values are computed in the order they were added.
+func (b *UserBuilder) WithLazyMetadata(key string, fn func(u *TestUser) any) *UserBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	key = b.namespacedKey(key)
+	if b.lockedMetadataKeys[key] {
+		b.AddWarning(fmt.Errorf("metadata key '%s' is locked, ignoring write", key))
+		return b
+	}
+	b.lazyMetadata = append(b.lazyMetadata, lazyMetadataValue{key: key, fn: fn})
+	b.recordAudit("WithLazyMetadata", key, nil)
+	return b
+}
+
+// UpdateMetadata applies a read-modify-write function to a metadata key.
+// The function receives the current value (nil if absent) and its result is stored under key.
+func (b *UserBuilder) UpdateMetadata(key string, fn func(old any) any) *UserBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	return b.WithMetadata(key, fn(b.user.Metadata[b.namespacedKey(key)]))
+}
+
+// WithEmailFrom sets the email from a round-robin pool, evaluated lazily at build time.
+// Each Build takes the next email, so a batch of users cycles through the pool deterministically.
+func (b *UserBuilder) WithEmailFrom(rr *RoundRobin[string]) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.emailSource = rr
+	b.recordAudit("WithEmailFrom", "email", rr)
+	return b
+}
+
+// WithLocale sets the name to a random name from the curated pool of locale, e.g. "de_DE" or "ja_JP",
+// written in the locale's order. Names are deterministic for a given rng; a nil rng uses the builder's
+// seeded source. Unknown locales fall back to DefaultLocale with a warning. See SupportedLocales.
+func (b *UserBuilder) WithLocale(locale string, rng *rand.Rand) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	names, exists := locales[locale]
+	if !exists {
+		b.AddWarning(fmt.Errorf("unknown locale '%s', falling back to %s", locale, DefaultLocale))
+		names = locales[DefaultLocale]
+	}
+	if rng == nil {
+		rng = b.seededRng()
+	}
+	return b.WithName(names.name(rng))
+}
+
+// WithNameFromPool sets the name to a random element of pool, picked at each build with rng.
+// Picks are deterministic for a given rng; a nil rng uses the builder's seeded source.
+// The pool takes precedence over WithName. An empty pool adds an error.
+func (b *UserBuilder) WithNameFromPool(pool []string, rng *rand.Rand) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if len(pool) == 0 {
+		b.AddError(&FieldError{Field: "name", Message: "name pool cannot be empty"})
+		return b
+	}
+	b.namePool = &valuePool{values: slices.Clone(pool), rng: rng}
+	b.recordAudit("WithNameFromPool", "name", slices.Clone(pool))
+	return b
+}
+
+// WithEmailFromPool sets the email to a random element of pool, picked at each build with rng.
+// Picks are deterministic for a given rng; a nil rng uses the builder's seeded source.
+// The pool takes precedence over WithEmail. An empty pool adds an error.
+func (b *UserBuilder) WithEmailFromPool(pool []string, rng *rand.Rand) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if len(pool) == 0 {
+		b.AddError(&FieldError{Field: "email", Message: "email pool cannot be empty"})
+		return b
+	}
+	b.emailPool = &valuePool{values: slices.Clone(pool), rng: rng}
+	b.recordAudit("WithEmailFromPool", "email", slices.Clone(pool))
+	return b
+}
+
+// WithEmailProvider generates the email at build time from the user's name when no email is set.
+// The provider draws randomness from the builder's seeded source, so seeded builders produce reproducible emails.
+func (b *UserBuilder) WithEmailProvider(provider EmailProvider) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.emailProvider = provider
 	return b
 }
 
+// WithRepair adds a repair callback invoked when Build detects validation errors.
+// The callback returns true if it changed the user; validation is then re-run,
+// up to a fixed number of passes, before giving up.
+func (b *UserBuilder) WithRepair(fn func(*TestUser) bool) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if fn != nil {
+		b.repairs = append(b.repairs, fn)
+	}
+	return b
+}
+
+// WithMetadataSchema requires the given metadata keys to be present with values of the given kinds.
+// The schema is checked at build time when validation is enabled, reporting each mismatch as a FieldError.
+func (b *UserBuilder) WithMetadataSchema(required map[string]reflect.Kind) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.metadataSchema = maps.Clone(required)
+	return b
+}
+
+// WithMutuallyExclusive forbids setting more than one of the given fields, checked at build time
+// when validation is enabled. User fields ("id", "name", "email", "age", "active") count as set once a
+// setter was called for them; any other name, optionally prefixed with "metadata.", is a metadata key
+// that counts as set when present. For example, WithMutuallyExclusive("age", "birthdate") rejects fixtures
+// setting both an age and a birthdate; note that WithBirthdate sets both.
+func (b *UserBuilder) WithMutuallyExclusive(fields ...string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if len(fields) > 1 {
+		b.exclusiveFields = append(b.exclusiveFields, slices.Clone(fields))
+	}
+	return b
+}
+
+// WithUserRef stores the ID of the user built by other in metadata under key.
+// The referenced builder is built at build time; cyclic references produce an error.
+func (b *UserBuilder) WithUserRef(key string, other *UserBuilder) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if other == nil {
+		b.AddError(fmt.Errorf("user reference '%s' cannot be nil", key))
+		return b
+	}
+	if b.userRefs == nil {
+		b.userRefs = make(map[string]*UserBuilder)
+	}
+	b.userRefs[key] = other
+	return b
+}
+
+// WithChildren attaches child builders, e.g. for a user's sessions. At build time each child is built
+// and the results are stored in metadata under key as a []any, in order.
+// The build fails with the index of the first child returning an error.
+func (b *UserBuilder) WithChildren(key string, builders ...Builder) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	for i, child := range builders {
+		if child == nil {
+			b.AddError(fmt.Errorf("child %d of '%s' cannot be nil", i, key))
+			return b
+		}
+	}
+	if b.children == nil {
+		b.children = make(map[string][]Builder)
+	}
+	b.children[key] = slices.Clone(builders)
+	return b
+}
+
+// WithStoreMaskedEmail stores the masked email of the built user in metadata under key.
+func (b *UserBuilder) WithStoreMaskedEmail(key string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.maskedEmailKey = key
+	return b
+}
+
+// WithCompositeKey computes a key at build time by joining the values of the named fields,
+// and stores it in metadata under CompositeKeyMetadataKey. Fields are "id", "name", "email",
+// "age", "active", or a metadata key written as "metadata.<key>".
+func (b *UserBuilder) WithCompositeKey(fields ...string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if len(fields) == 0 {
+		b.AddError(errors.New("composite key requires at least one field"))
+		return b
+	}
+	for _, field := range fields {
+		if _, ok := (&TestUser{}).fieldString(field); !ok {
+			b.AddError(fmt.Errorf("unsupported composite key field '%s'", field))
+			return b
+		}
+	}
+	b.compositeKeyFields = slices.Clone(fields)
+	return b
+}
+
+// WithMinimalUser sets a placeholder name and email, each only if not already set,
+// so that Build succeeds without further input. Use it when a test just needs any valid user.
+func (b *UserBuilder) WithMinimalUser() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if !b.IsFieldSet("name") {
+		b.WithName(minimalUserName)
+	}
+	if !b.IsFieldSet("email") {
+		b.WithEmail(minimalUserEmail)
+	}
+	return b
+}
+
+// WithDefaultsFromEnv sets fields from the environment variables <prefix>NAME, <prefix>EMAIL,
+// <prefix>AGE and <prefix>ACTIVE. Only fields whose variable exists are set; values that don't
+// parse as an int (age) or bool (active) add a validation error.
+func (b *UserBuilder) WithDefaultsFromEnv(prefix string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if name, ok := os.LookupEnv(prefix + "NAME"); ok {
+		b.WithName(name)
+	}
+	if email, ok := os.LookupEnv(prefix + "EMAIL"); ok {
+		b.WithEmail(email)
+	}
+	if value, ok := os.LookupEnv(prefix + "AGE"); ok {
+		if age, err := strconv.Atoi(value); err != nil {
+			b.AddError(&FieldError{Field: "age", Message: fmt.Sprintf("invalid %sAGE value '%s'", prefix, value)})
+		} else {
+			b.WithAge(age)
+		}
+	}
+	if value, ok := os.LookupEnv(prefix + "ACTIVE"); ok {
+		if active, err := strconv.ParseBool(value); err != nil {
+			b.AddError(&FieldError{Field: "active", Message: fmt.Sprintf("invalid %sACTIVE value '%s'", prefix, value)})
+		} else {
+			b.WithActive(active)
+		}
+	}
+	return b
+}
+
+// WithAgeOptional treats an age that was never set with WithAge as absent rather than zero:
+// it is left out of BuildPatch and of struct validation, while an explicit zero age is kept.
+func (b *UserBuilder) WithAgeOptional() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.ageOptional = true
+	return b
+}
+
+// WithStoreCanonicalEmail stores the canonical email of the built user in metadata under key.
+func (b *UserBuilder) WithStoreCanonicalEmail(key string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.canonicalEmailKey = key
+	return b
+}
+
+// MirrorTagsToMetadata copies the builder tags into the built user's metadata at build time,
+// as a map[string]string under TagsMetadataKey, so assertions reading metadata also see the tags.
+func (b *UserBuilder) MirrorTagsToMetadata() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.mirrorTags = true
+	return b
+}
+
+// MirrorMetadataToTags copies the string metadata values under keys into builder tags of the same name.
+// Missing keys and non-string values are skipped with a warning.
+func (b *UserBuilder) MirrorMetadataToTags(keys ...string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	for _, key := range keys {
+		value, exists := b.user.Metadata[key]
+		if !exists {
+			b.AddWarning(fmt.Errorf("metadata key '%s' not found, not mirrored to tags", key))
+			continue
+		}
+		text, ok := value.(string)
+		if !ok {
+			b.AddWarning(fmt.Errorf("metadata key '%s' holds %T, not a string, not mirrored to tags", key, value))
+			continue
+		}
+		b.WithTag(key, text)
+	}
+	return b
+}
+
+// WithMaxMetadataBytes limits the size of the JSON-encoded metadata to n bytes, checked at build time
+// when validation is enabled, to catch fixtures accidentally embedding huge blobs.
+// A non-positive n removes the limit.
+func (b *UserBuilder) WithMaxMetadataBytes(n int) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.maxMetadataBytes = n
+	return b
+}
+
+// ApplyStructDefaults sets every field not explicitly set on the builder to its `testkit:"default=V"` tag value,
+// keeping the defaults next to the TestUser field definitions.
+func (b *UserBuilder) ApplyStructDefaults() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	applied, err := applyStructDefaults(b.user, func(field string) bool {
+		return b.setFields[strings.ToLower(field)]
+	})
+	if err != nil {
+		b.AddError(err)
+	}
+	for _, field := range applied {
+		b.markSet(strings.ToLower(field))
+	}
+	return b
+}
+
+// WithStructValidation enables validation of the built user against its `testkit` struct tags.
+func (b *UserBuilder) WithStructValidation(enabled bool) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.structValidation = enabled
+	return b
+}
+
+// Seed implements Seedable by setting the random source used by Randomize.
+func (b *UserBuilder) Seed(seed int64) {
+	b.seedRng(seed)
+	b.dirty = true
+}
+
+// seedRng sets the random source from seed. Unlike Seed, it doesn't invalidate memoized builds,
+// so seeding lazily during a build doesn't count as a mutation.
+func (b *UserBuilder) seedRng(seed int64) {
+	b.seed = seed
+	b.cloneCount = 0
+	b.rng = rand.New(rand.NewPCG(uint64(seed), uint64(seed))) //nolint:gosec // deterministic test data, not security sensitive
+}
+
+// seededRng returns the builder's random source, seeding it randomly if Seed wasn't called.
+func (b *UserBuilder) seededRng() *rand.Rand {
+	if b.rng == nil {
+		b.seedRng(rand.Int64()) //nolint:gosec // test data, not security sensitive
+	}
+	return b.rng
+}
+
+// CloneSeed returns the seed of the builder's random source: the one given to Seed,
+// or the one derived by Clone. It returns 0 if the builder has no random source.
+func (b *UserBuilder) CloneSeed() int64 {
+	return b.seed
+}
+
+// cloneSeedState implements cloneSeeder, capturing the seed, random source and clone counter.
+func (b *UserBuilder) cloneSeedState() cloneSeedState {
+	return cloneSeedState{seed: b.seed, rng: b.rng, cloneCount: b.cloneCount}
+}
+
+// restoreCloneSeedState implements cloneSeeder, restoring the state captured by cloneSeedState.
+func (b *UserBuilder) restoreCloneSeedState(state cloneSeedState) {
+	b.seed, b.rng, b.cloneCount = state.seed, state.rng, state.cloneCount
+}
+
+// deriveCloneSeed derives the seed of the index-th clone of a builder seeded with seed,
+// mixing the bits with the SplitMix64 finalizer so that nearby seeds and indices don't collide.
+func deriveCloneSeed(seed int64, index int) int64 {
+	z := uint64(seed) + uint64(index)*0x9e3779b97f4a7c15 //nolint:gosec // bits are reinterpreted on purpose
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31)) //nolint:gosec // bits are reinterpreted on purpose
+}
+
+// Randomize fills the user fields with random values.
+// The values are reproducible when the builder was seeded with Seed.
+func (b *UserBuilder) Randomize() *UserBuilder {
+	return b.randomizeFields(randomizableFields...)
+}
+
+// randomizeFields sets the given fields to random values and records them as randomly generated.
+// All values are drawn on every call, so the sequence for a seed doesn't depend on the fields.
+func (b *UserBuilder) randomizeFields(fields ...string) *UserBuilder {
+	rng := b.seededRng()
+	first := randomFirstNames[rng.IntN(len(randomFirstNames))]
+	last := randomLastNames[rng.IntN(len(randomLastNames))]
+	id := rng.IntN(randomMaxID) + 1
+	age := randomMinAge + rng.IntN(randomMaxAge-randomMinAge+1)
+	active := rng.IntN(2) == 0
+
+	for _, field := range fields {
+		switch field {
+		case "id":
+			b.WithID(id)
+		case "name":
+			b.WithName(first + " " + last)
+		case "email":
+			b.WithEmail(fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), id))
+		case "age":
+			b.WithAge(age)
+		case "active":
+			b.WithActive(active)
+		}
+	}
+	if b.randomFields == nil {
+		b.randomFields = make(map[string]bool)
+	}
+	for _, field := range fields {
+		b.randomFields[field] = true
+	}
+	return b
+}
+
+// WithWeightedActive sets the active flag at random, following the relative weights of active and inactive,
+// e.g. WithWeightedActive(0.8, 0.2) for 80% active users. The draw uses the random source set by Seed.
+// Negative weights, or both weights zero, add a validation error.
+func (b *UserBuilder) WithWeightedActive(active, inactive float64) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	choice, err := NewWeightedChoice(
+		WeightedOption[bool]{Value: true, Weight: active},
+		WeightedOption[bool]{Value: false, Weight: inactive},
+	)
+	if err != nil {
+		b.AddError(&FieldError{Field: "active", Message: err.Error()})
+		return b
+	}
+	return b.WithActive(choice.Pick(b.seededRng()))
+}
+
+// BuildWithRetry builds the user, and on a validation failure re-randomizes the offending fields
+// and retries, up to maxAttempts builds in total. Only fields generated by Randomize are re-randomized:
+// when a failure can't be attributed to a field, all of them are; when no offending field is random,
+// the error is returned immediately. The last error is returned if all attempts fail.
+func (b *UserBuilder) BuildWithRetry(maxAttempts int) (any, error) {
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("invalid number of attempts %d", maxAttempts)
+	}
+
+	for attempt := 1; ; attempt++ {
+		result := b.Build()
+		err, isError := result.(error)
+		if !isError {
+			return result, nil
+		}
+		if attempt == maxAttempts || b.HasErrors() || b.rng == nil {
+			return nil, err
+		}
+
+		fields := b.offendingRandomFields(err)
+		if len(fields) == 0 {
+			return nil, err
+		}
+		b.randomizeFields(fields...)
+	}
+}
+
+// offendingRandomFields returns the randomly generated fields blamed by a validation error, in a stable order.
+func (b *UserBuilder) offendingRandomFields(err error) []string {
+	var fields []string
+	for _, fieldErr := range fieldErrorsOf(err) {
+		if fieldErr.Field == "" {
+			fields = slices.Clone(randomizableFields)
+			break
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	return slices.DeleteFunc(slices.Compact(slices.Sorted(slices.Values(fields))), func(field string) bool {
+		return !b.randomFields[field]
+	})
+}
+
+// MutateUser lets builders embedding UserBuilder modify the in-progress user directly.
+// It is intended for extensions that have no access to the unexported user field;
+// regular callers should prefer the With* methods, which perform validation.
+func (b *UserBuilder) MutateUser(fn func(*TestUser)) *UserBuilder {
+	if !b.Mutable() || fn == nil {
+		return b
+	}
+	fn(b.user)
+	b.recordAudit("MutateUser", "user", nil)
+	return b
+}
+
+// Apply runs each transform on the builder in order and returns the builder,
+// composing reusable configuration functions inline. Nil transforms are skipped.
+func (b *UserBuilder) Apply(fns ...func(*UserBuilder) *UserBuilder) *UserBuilder {
+	return b.apply(false, fns)
+}
+
+// ApplyUntilError behaves like Apply, but skips the remaining transforms once one adds an error.
+func (b *UserBuilder) ApplyUntilError(fns ...func(*UserBuilder) *UserBuilder) *UserBuilder {
+	return b.apply(true, fns)
+}
+
+// apply runs the transforms in order, stopping after one adding an error if stopOnError is set.
+func (b *UserBuilder) apply(stopOnError bool, fns []func(*UserBuilder) *UserBuilder) *UserBuilder {
+	for _, fn := range fns {
+		if fn == nil {
+			continue
+		}
+		errorCount := len(b.GetErrors())
+		fn(b)
+		if stopOnError && len(b.GetErrors()) > errorCount {
+			break
+		}
+	}
+	return b
+}
+
+// WithGroup assigns the user to a group, stored in metadata under GroupMetadataKey.
+// The key is never namespaced, so GroupByGroup always finds it, but locks and the audit trail apply
+// as with WithMetadata.
+func (b *UserBuilder) WithGroup(name string) *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	return b.writeMetadata("WithGroup", GroupMetadataKey, name)
+}
+
 // Build creates the TestUser instance.
 // It performs final validation and returns the user or an error.
 func (b *UserBuilder) Build() any {
+	return b.BuildContext(context.Background())
+}
+
+// BuildContext implements ContextBuilder. It behaves like Build, passing ctx to context-aware hooks,
+// and returns the context error if ctx is cancelled during a simulated latency.
+func (b *UserBuilder) BuildContext(ctx context.Context) any {
+	if b.building {
+		return ErrCyclicReference
+	}
+	b.building = true
+	defer func() { b.building = false }()
+	defer b.EnterGate()()
+
+	if err := b.SimulateLatency(ctx); err != nil {
+		return fmt.Errorf("cannot build user: %w", err)
+	}
+	if err := b.RunBeforeBuildHooks(ctx); err != nil {
+		return fmt.Errorf("cannot build user: %w", err)
+	}
 	if b.HasErrors() {
-		return fmt.Errorf("cannot build user due to validation errors: %v", b.GetErrors())
+		return fmt.Errorf("cannot build user due to validation errors: %w", errors.Join(b.GetErrors()...))
+	}
+
+	// Create a copy to avoid mutation
+	result := copyUser(b.user)
+
+	if err := b.resolveUserRefs(result); err != nil {
+		return err
+	}
+	if err := b.buildChildren(result); err != nil {
+		return err
+	}
+
+	// Resolve lazily evaluated fields
+	if b.namePool != nil {
+		result.Name = b.namePool.pick(b.seededRng)
+	}
+	if b.emailSource != nil {
+		result.Email = b.emailSource.Next()
+	}
+	if b.emailPool != nil {
+		result.Email = b.emailPool.pick(b.seededRng)
+	}
+	if result.Email == "" && b.emailProvider != nil {
+		result.Email = b.emailProvider.Generate(result.Name, b.seededRng())
+	}
+	if b.contentDerivedID {
+		result.ID = contentID(result.Name, result.Email)
+	}
+	for _, lazy := range b.lazyMetadata {
+		result.Metadata[lazy.key] = lazy.fn(result)
+	}
+
+	if err := b.validateWithRepairs(result); err != nil {
+		return err
+	}
+
+	b.storeDerivedMetadata(result)
+
+	if err := b.RunAfterBuildHooks(ctx, result); err != nil {
+		return fmt.Errorf("cannot build user: %w", err)
+	}
+
+	b.RecordBuild()
+	return result
+}
+
+// BuildPatch builds the user and returns its non-zero fields keyed by "id", "name", "email", "age" and "active",
+// as sent in a partial update. With WithAgeOptional, age is included exactly when it was set, even to zero.
+func (b *UserBuilder) BuildPatch() (map[string]any, error) {
+	result := b.Build()
+	if err, isError := result.(error); isError {
+		return nil, err
+	}
+	user, _ := result.(*TestUser)
+
+	patch := make(map[string]any)
+	if user.ID != 0 {
+		patch["id"] = user.ID
+	}
+	if user.Name != "" {
+		patch["name"] = user.Name
+	}
+	if user.Email != "" {
+		patch["email"] = user.Email
+	}
+	if b.ageOptional && b.IsFieldSet("age") || !b.ageOptional && user.Age != 0 {
+		patch["age"] = user.Age
+	}
+	if user.Active {
+		patch["active"] = user.Active
+	}
+	return patch, nil
+}
+
+// BuildT builds the user, failing tb with the build error if any, so tests can skip the type switch.
+// It returns nil only if tb.Fatalf returns, which real testing.TB implementations never do.
+func (b *UserBuilder) BuildT(tb testing.TB) *TestUser {
+	tb.Helper()
+	result := b.Build()
+	if err, isError := result.(error); isError {
+		tb.Fatalf("cannot build user: %v", err)
+		return nil
+	}
+	user, _ := result.(*TestUser)
+	return user
+}
+
+// BuildMemoized builds the user once and returns copies of the cached result on later calls,
+// until the builder is mutated or reset. Each call returns an independent copy, so callers cannot
+// corrupt the cache. Lazy generators such as WithEmailFrom are only advanced by the first build.
+// Build errors are returned as with Build and are not cached.
+func (b *UserBuilder) BuildMemoized() any {
+	if b.memoized != nil && !b.dirty {
+		return copyUser(b.memoized)
+	}
+
+	b.dirty = false
+	result := b.Build()
+	user, ok := result.(*TestUser)
+	if !ok {
+		b.memoized = nil
+		return result
+	}
+	b.memoized = copyUser(user)
+	return user
+}
+
+// storeDerivedMetadata stores metadata computed from the validated user.
+func (b *UserBuilder) storeDerivedMetadata(user *TestUser) {
+	if b.maskedEmailKey != "" {
+		user.Metadata[b.maskedEmailKey] = user.MaskedEmail()
+	}
+	if b.canonicalEmailKey != "" {
+		user.Metadata[b.canonicalEmailKey] = user.CanonicalEmail()
+	}
+	if len(b.compositeKeyFields) > 0 {
+		parts := make([]string, len(b.compositeKeyFields))
+		for i, field := range b.compositeKeyFields {
+			parts[i], _ = user.fieldString(field)
+		}
+		user.Metadata[CompositeKeyMetadataKey] = strings.Join(parts, compositeKeySeparator)
+	}
+	if b.mirrorTags {
+		user.Metadata[TagsMetadataKey] = b.GetTags()
+	}
+}
+
+// resolveUserRefs builds the referenced users and stores their IDs in the user metadata.
+func (b *UserBuilder) resolveUserRefs(user *TestUser) error {
+	for _, key := range slices.Sorted(maps.Keys(b.userRefs)) {
+		switch ref := b.userRefs[key].Build().(type) {
+		case *TestUser:
+			user.Metadata[key] = ref.ID
+		case error:
+			return fmt.Errorf("cannot resolve user reference '%s': %w", key, ref)
+		}
+	}
+	return nil
+}
+
+// ValidationReport runs the build-time validation on a preview of the user and returns a structured summary,
+// including errors recorded while configuring the builder. It doesn't build the user, so
+// repairs, user references, and build hooks are not run, and lazy generators are not advanced.
+func (b *UserBuilder) ValidationReport() Report {
+	fieldErrors := fieldErrorsOf(errors.Join(b.GetErrors()...))
+	fieldErrors = append(fieldErrors, fieldErrorsOf(b.validateUser(b.previewUser()))...)
+	tags := make(map[string]string, len(b.tags))
+	for key := range b.tags {
+		if b.HasTag(key) {
+			tags[key] = b.tags[key]
+		}
+	}
+	return Report{
+		Valid:       len(fieldErrors) == 0,
+		FieldErrors: fieldErrors,
+		Warnings:    b.GetWarnings(),
+		Tags:        tags,
+	}
+}
+
+// ExplainErrors returns a remediation hint for each error caused by a known sentinel error,
+// both recorded while configuring the builder and found by validating a preview of the user.
+// For example, a missing email yields a hint to call WithEmail.
+func (b *UserBuilder) ExplainErrors() []string {
+	return explainErrors(errors.Join(errors.Join(b.GetErrors()...), b.validateUser(b.previewUser())))
+}
+
+// previewUser returns a copy of the user with lazily evaluated fields filled in, without advancing generators.
+func (b *UserBuilder) previewUser() *TestUser {
+	// A throwaway source keeps the builder's random sequences untouched
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec // test data, not security sensitive
+	preview := copyUser(b.user)
+	if b.namePool != nil {
+		preview.Name = b.namePool.values[rng.IntN(len(b.namePool.values))]
+	}
+	if b.emailSource != nil {
+		preview.Email = b.emailSource.Peek()
+	}
+	if b.emailPool != nil {
+		preview.Email = b.emailPool.values[rng.IntN(len(b.emailPool.values))]
+	}
+	if preview.Email == "" && b.emailProvider != nil {
+		preview.Email = b.emailProvider.Generate(preview.Name, rng)
+	}
+	return preview
+}
+
+// buildChildren builds the child builders and stores the results in the user metadata.
+func (b *UserBuilder) buildChildren(user *TestUser) error {
+	for _, key := range slices.Sorted(maps.Keys(b.children)) {
+		built := make([]any, 0, len(b.children[key]))
+		for i, child := range b.children[key] {
+			result := child.Build()
+			if err, isError := result.(error); isError {
+				return fmt.Errorf("cannot build child %d of '%s': %w", i, key, err)
+			}
+			built = append(built, result)
+		}
+		user.Metadata[key] = built
 	}
+	return nil
+}
+
+// ValidateGroup runs only the validators registered in group, with the UserBuilder as target.
+func (b *UserBuilder) ValidateGroup(group string) error {
+	return b.runValidatorGroup(b, group)
+}
 
-	// Perform final validation
+// ValidateAsync shadows BaseBuilder.ValidateAsync so validators receive the UserBuilder as target.
+func (b *UserBuilder) ValidateAsync(ctx context.Context) error {
+	return b.validateAsync(ctx, b, DefaultValidationGroup)
+}
+
+// validateWithRepairs validates the user, running repair callbacks and re-validating on failure.
+func (b *UserBuilder) validateWithRepairs(user *TestUser) error {
+	err := b.validateUser(user)
+	for pass := 0; err != nil && pass < maxRepairPasses; pass++ {
+		changed := false
+		for _, repair := range b.repairs {
+			if repair(user) {
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		err = b.validateUser(user)
+	}
+	return err
+}
+
+// validateUser performs the final validation of an assembled user.
+// All failures are aggregated with errors.Join so sentinels can be matched with errors.Is.
+func (b *UserBuilder) validateUser(user *TestUser) error {
+	var errs []error
 	if b.IsValidationEnabled() {
-		if b.user.Name == "" {
-			return errors.New("user name is required")
+		b.validating = user
+		usesProfile, err := b.runValidationProfile(b)
+		b.validating = nil
+		if usesProfile {
+			errs = append(errs, err)
+		} else {
+			errs = append(errs, b.validateRequiredFields(user))
 		}
-		if b.user.Email == "" {
-			return errors.New("user email is required")
+		errs = append(errs, b.validateMetadataSchema(user), validateMetadataValues(user), b.validateMetadataSize(user),
+			b.validateExclusiveFields(user), b.RunValidators(b))
+	}
+
+	if b.structValidation {
+		var skip map[string]bool
+		if b.ageOptional && !b.IsFieldSet("age") {
+			skip = map[string]bool{"Age": true}
+		}
+		if err := validateStruct(user, skip); err != nil {
+			errs = append(errs, fmt.Errorf("user failed struct validation: %w", err))
 		}
 	}
+	return errors.Join(errs...)
+}
 
-	// Create a copy to avoid mutation
+// validateRequiredFields applies the built-in rules requiring a name and an email.
+func (b *UserBuilder) validateRequiredFields(user *TestUser) error {
+	var errs []error
+	if user.Name == "" {
+		errs = append(errs, newSentinelError(
+			b.formatError("name", "required", user.Name, ErrNameRequired.Error()), ErrNameRequired))
+	}
+	if user.Email == "" {
+		errs = append(errs, newSentinelError(
+			b.formatError("email", "required", user.Email, ErrEmailRequired.Error()), ErrEmailRequired))
+	}
+	return errors.Join(errs...)
+}
+
+// validateMetadataSize checks the JSON-encoded size of the user metadata against the configured limit.
+func (b *UserBuilder) validateMetadataSize(user *TestUser) error {
+	if b.maxMetadataBytes <= 0 {
+		return nil
+	}
+	data, err := json.Marshal(user.Metadata)
+	if err != nil {
+		return &FieldError{Field: "metadata", Message: fmt.Sprintf("cannot measure size: %v", err)}
+	}
+	if len(data) > b.maxMetadataBytes {
+		return &FieldError{
+			Field:   "metadata",
+			Message: fmt.Sprintf("size of %d bytes exceeds the limit of %d bytes", len(data), b.maxMetadataBytes),
+		}
+	}
+	return nil
+}
+
+// validateExclusiveFields reports each group of mutually exclusive fields with more than one field set.
+func (b *UserBuilder) validateExclusiveFields(user *TestUser) error {
+	var errs []error
+	for _, fields := range b.exclusiveFields {
+		var set []string
+		for _, field := range fields {
+			if b.isExclusiveFieldSet(user, field) {
+				set = append(set, field)
+			}
+		}
+		if len(set) > 1 {
+			errs = append(errs, fmt.Errorf("mutually exclusive fields set together: %s", strings.Join(set, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isExclusiveFieldSet reports whether a user field was explicitly set, or a metadata key is present.
+func (b *UserBuilder) isExclusiveFieldSet(user *TestUser, field string) bool {
+	if key, isMetadata := strings.CutPrefix(field, "metadata."); isMetadata {
+		_, exists := user.Metadata[key]
+		return exists
+	}
+	switch field {
+	case "id", "name", "email", "age", "active":
+		return b.IsFieldSet(field)
+	default:
+		_, exists := user.Metadata[field]
+		return exists
+	}
+}
+
+// validateMetadataSchema checks the user metadata against the configured schema.
+func (b *UserBuilder) validateMetadataSchema(user *TestUser) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(b.metadataSchema)) {
+		field := "metadata." + key
+		value, exists := user.Metadata[key]
+		if !exists {
+			errs = append(errs, &FieldError{Field: field, Message: "required metadata key is missing"})
+			continue
+		}
+		expected := b.metadataSchema[key]
+		if actual := reflect.ValueOf(value).Kind(); actual != expected {
+			errs = append(errs, &FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("expected kind %s, got %s", expected, actual),
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateMetadataValues runs Validate on every metadata value implementing Validatable.
+func validateMetadataValues(user *TestUser) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(user.Metadata)) {
+		if validatable, ok := user.Metadata[key].(Validatable); ok {
+			if err := validatable.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("metadata '%s': %w", key, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// copyUser creates a deep copy of a TestUser.
+func copyUser(user *TestUser) *TestUser {
 	result := &TestUser{
-		ID:       b.user.ID,
-		Name:     b.user.Name,
-		Email:    b.user.Email,
-		Age:      b.user.Age,
-		Active:   b.user.Active,
-		Tags:     make(map[string]string),
-		Metadata: make(map[string]any),
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Age:           user.Age,
+		Active:        user.Active,
+		Tags:          make(map[string]string),
+		Metadata:      make(map[string]any),
+		SchemaVersion: user.SchemaVersion,
 	}
 
 	// Deep copy tags
-	maps.Copy(result.Tags, b.user.Tags)
+	maps.Copy(result.Tags, user.Tags)
 
 	// Deep copy metadata
-	maps.Copy(result.Metadata, b.user.Metadata)
+	maps.Copy(result.Metadata, user.Metadata)
 
 	return result
 }
 
+// CaptureTemplate snapshots the current user state so it can be restored by ResetToTemplate.
+func (b *UserBuilder) CaptureTemplate() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	b.template = &userSnapshot{
+		user:      copyUser(b.user),
+		setFields: maps.Clone(b.setFields),
+	}
+	return b
+}
+
+// ResetToTemplate restores the user state captured by CaptureTemplate and clears errors and warnings.
+// Builder configuration such as tags and validation is kept.
+func (b *UserBuilder) ResetToTemplate() *UserBuilder {
+	if !b.Mutable() {
+		return b
+	}
+	if b.template == nil {
+		b.AddError(errors.New("no template captured"))
+		return b
+	}
+	b.user = copyUser(b.template.user)
+	b.setFields = maps.Clone(b.template.setFields)
+	b.ClearErrors()
+	b.ClearWarnings()
+	return b
+}
+
+// ReadOnly returns a read-only view of the builder whose Preview returns a copy of the in-progress user.
+func (b *UserBuilder) ReadOnly() BuilderView {
+	view := b.BaseBuilder.ReadOnly()
+	view.preview = func() any {
+		return copyUser(b.user)
+	}
+	return view
+}
+
+// OutputType implements TypedBuilder.
+func (b *UserBuilder) OutputType() reflect.Type {
+	return reflect.TypeFor[*TestUser]()
+}
+
 // Reset clears the builder state for reuse.
 func (b *UserBuilder) Reset() Builder {
 	b.BaseBuilder.Reset()
 	b.user = &TestUser{
-		Tags:     make(map[string]string),
-		Metadata: make(map[string]any),
+		Tags:          make(map[string]string),
+		Metadata:      make(map[string]any),
+		SchemaVersion: CurrentSchemaVersion,
 	}
+	b.structValidation = false
+	b.contentDerivedID = false
+	b.ageOptional = false
+	b.maxMetadataBytes = 0
+	b.maxNameLength = 0
+	b.strictNameLength = false
+	b.setFields = make(map[string]bool)
+	b.rng = nil
+	b.seed = 0
+	b.cloneCount = 0
+	b.randomFields = nil
+	b.emailSource = nil
+	b.emailProvider = nil
+	b.namePool = nil
+	b.emailPool = nil
+	b.repairs = nil
+	b.metadataSchema = nil
+	b.exclusiveFields = nil
+	b.userRefs = nil
+	b.children = nil
+	b.template = nil
+	b.metadataNamespace = ""
+	b.detectConflicts = false
+	b.strictConflicts = false
+	b.lazyMetadata = nil
+	b.lockedMetadataKeys = nil
+	b.maskedEmailKey = ""
+	b.canonicalEmailKey = ""
+	b.mirrorTags = false
+	b.memoized = nil
+	b.compositeKeyFields = nil
 	return b
 }
 
+// CloneConfig returns a fresh UserBuilder sharing the BaseBuilder configuration of b, such as tags,
+// validation settings and validators, with the user left at its zero values.
+// It is cheaper than Clone followed by Reset and states the intent more clearly.
+func (b *UserBuilder) CloneConfig() Builder {
+	clone := NewUserBuilder()
+	clone.BaseBuilder = b.cloneConfig()
+	return clone
+}
+
 // Clone creates a deep copy of the UserBuilder.
+// Shared generators such as the email source are goroutine-safe and kept shared.
+// The clone of a seeded builder is seeded with a seed derived from the parent's seed and the clone's index,
+// so successive clones are distinct from each other and the same clone tree is reproducible.
 func (b *UserBuilder) Clone() Builder {
 	baseClone, _ := b.BaseBuilder.Clone().(*BaseBuilder)
 	clone := &UserBuilder{
-		BaseBuilder: baseClone,
-		user: &TestUser{
-			ID:       b.user.ID,
-			Name:     b.user.Name,
-			Email:    b.user.Email,
-			Age:      b.user.Age,
-			Active:   b.user.Active,
-			Tags:     make(map[string]string),
-			Metadata: make(map[string]any),
-		},
+		BaseBuilder:        baseClone,
+		user:               copyUser(b.user),
+		structValidation:   b.structValidation,
+		contentDerivedID:   b.contentDerivedID,
+		ageOptional:        b.ageOptional,
+		maxMetadataBytes:   b.maxMetadataBytes,
+		maxNameLength:      b.maxNameLength,
+		strictNameLength:   b.strictNameLength,
+		setFields:          maps.Clone(b.setFields),
+		randomFields:       maps.Clone(b.randomFields),
+		emailSource:        b.emailSource,
+		emailProvider:      b.emailProvider,
+		namePool:           b.namePool,
+		emailPool:          b.emailPool,
+		repairs:            slices.Clone(b.repairs),
+		metadataSchema:     maps.Clone(b.metadataSchema),
+		exclusiveFields:    slices.Clone(b.exclusiveFields),
+		userRefs:           maps.Clone(b.userRefs),
+		children:           maps.Clone(b.children),
+		template:           b.template,
+		metadataNamespace:  b.metadataNamespace,
+		detectConflicts:    b.detectConflicts,
+		strictConflicts:    b.strictConflicts,
+		lazyMetadata:       slices.Clone(b.lazyMetadata),
+		lockedMetadataKeys: maps.Clone(b.lockedMetadataKeys),
+		maskedEmailKey:     b.maskedEmailKey,
+		canonicalEmailKey:  b.canonicalEmailKey,
+		mirrorTags:         b.mirrorTags,
+		compositeKeyFields: slices.Clone(b.compositeKeyFields),
 	}
 
-	// Deep copy user tags
-	maps.Copy(clone.user.Tags, b.user.Tags)
-
-	// Deep copy user metadata
-	maps.Copy(clone.user.Metadata, b.user.Metadata)
+	// Seed the clone from a seed derived from this builder's seed and the clone's index,
+	// so the clone tree is reproducible and sibling clones generate distinct values
+	if b.rng != nil {
+		b.cloneCount++
+		clone.Seed(deriveCloneSeed(b.seed, b.cloneCount))
+	}
 
 	return clone
 }
@@ -220,7 +1663,61 @@ func createUserBuilder() Builder {
 	return NewUserBuilder()
 }
 
+// strictUserRules require a name, a well-formed email, and a positive age.
+func strictUserRules() []func(Builder) error {
+	return []func(Builder) error{
+		userRule(func(user *TestUser) error {
+			if user.Name == "" {
+				return ErrNameRequired
+			}
+			return nil
+		}),
+		userRule(func(user *TestUser) error {
+			if !isValidEmail(user.Email) {
+				return &FieldError{Field: "email", Message: "must be a valid email address"}
+			}
+			return nil
+		}),
+		userRule(func(user *TestUser) error {
+			if user.Age <= 0 {
+				return &FieldError{Field: "age", Message: "must be positive"}
+			}
+			return nil
+		}),
+	}
+}
+
+// relaxedUserRules only require the user to be identifiable by a name or an email.
+func relaxedUserRules() []func(Builder) error {
+	return []func(Builder) error{
+		userRule(func(user *TestUser) error {
+			if user.Name == "" && user.Email == "" {
+				return errors.New("user requires a name or an email")
+			}
+			return nil
+		}),
+	}
+}
+
+// userRule adapts a rule on the assembled user to a validation profile rule.
+// At build time the rule sees the user with lazily generated fields and repairs applied;
+// called outside a build, it sees the configured user. Builders other than UserBuilder pass the rule unchecked.
+func userRule(rule func(*TestUser) error) func(Builder) error {
+	return func(b Builder) error {
+		userBuilder, ok := b.(*UserBuilder)
+		if !ok {
+			return nil
+		}
+		if userBuilder.validating != nil {
+			return rule(userBuilder.validating)
+		}
+		return rule(userBuilder.user)
+	}
+}
+
 // Register UserBuilder in the default factory.
 func init() { //nolint:gochecknoinits // factory registration requires init
 	_ = RegisterBuilder("user", createUserBuilder)
+	_ = RegisterValidationProfile("strict", strictUserRules())
+	_ = RegisterValidationProfile("relaxed", relaxedUserRules())
 }
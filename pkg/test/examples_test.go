@@ -1,7 +1,16 @@
 package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestUserBuilder_NewUserBuilder(t *testing.T) {
@@ -316,3 +325,1687 @@ func TestUserBuilder_ApplyConfig(t *testing.T) {
 		t.Error("Expected error with nil config")
 	}
 }
+
+func TestUserBuilder_WithClampedAge(t *testing.T) {
+	tests := []struct {
+		name        string
+		age         int
+		expected    int
+		wantWarning bool
+	}{
+		{name: "below range", age: 10, expected: 18, wantWarning: true},
+		{name: "in range", age: 30, expected: 30, wantWarning: false},
+		{name: "above range", age: 150, expected: 99, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewUserBuilder()
+			result := builder.WithClampedAge(tt.age, 18, 99)
+			if result != builder {
+				t.Error("WithClampedAge should return the same builder instance")
+			}
+
+			if builder.user.Age != tt.expected {
+				t.Errorf("Expected age %d, got %d", tt.expected, builder.user.Age)
+			}
+			if builder.HasWarnings() != tt.wantWarning {
+				t.Errorf("Expected warning %v, got warnings %v", tt.wantWarning, builder.GetWarnings())
+			}
+			if builder.HasErrors() {
+				t.Errorf("Expected no errors, got %v", builder.GetErrors())
+			}
+		})
+	}
+
+	builder := NewUserBuilder()
+	builder.WithClampedAge(30, 99, 18)
+	if !builder.HasErrors() {
+		t.Error("Expected error for inverted range")
+	}
+}
+
+func TestTestUser_ToBuilder(t *testing.T) {
+	original, ok := NewUserBuilder().
+		WithID(7).
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithAge(30).
+		WithActive(true).
+		WithUserTag("role", "admin").
+		WithMetadata("created_by", "test").
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected original user to build")
+	}
+
+	builder := original.ToBuilder()
+	for _, field := range []string{"id", "name", "email", "age", "active"} {
+		if !builder.IsFieldSet(field) {
+			t.Errorf("Expected field %q to be marked as set", field)
+		}
+	}
+
+	rebuilt, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected round-tripped user to build")
+	}
+	if !reflect.DeepEqual(original, rebuilt) {
+		t.Errorf("Expected round-trip equality, got %+v and %+v", original, rebuilt)
+	}
+
+	// The builder must hold a deep copy of the user
+	builder.WithUserTag("role", "guest").WithMetadata("created_by", "other")
+	if original.Tags["role"] != "admin" || original.Metadata["created_by"] != "test" {
+		t.Error("Modifying the builder should not affect the original user")
+	}
+}
+
+func TestUserBuilder_WithRepair(t *testing.T) {
+	calls := 0
+	builder := NewUserBuilder().
+		WithName("John Doe").
+		WithRepair(func(user *TestUser) bool {
+			calls++
+			if user.Email == "" {
+				user.Email = "repaired@example.com"
+				return true
+			}
+			return false
+		})
+
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected repaired user to build successfully")
+	}
+	if user.Email != "repaired@example.com" {
+		t.Errorf("Expected repaired email, got %q", user.Email)
+	}
+	if calls != 1 {
+		t.Errorf("Expected repair to run once, got %d", calls)
+	}
+	if builder.user.Email != "" {
+		t.Error("Repairs should apply to the built copy, not the builder state")
+	}
+
+	// A repair that can't fix the problem gives up after a bounded number of passes
+	calls = 0
+	failing := NewUserBuilder().WithRepair(func(*TestUser) bool {
+		calls++
+		return true
+	})
+	if _, isError := failing.Build().(error); !isError {
+		t.Error("Expected build to fail when repairs can't fix validation")
+	}
+	if calls != maxRepairPasses {
+		t.Errorf("Expected %d repair passes, got %d", maxRepairPasses, calls)
+	}
+}
+
+func TestUserBuilder_WithMetadataSchema(t *testing.T) {
+	schema := map[string]reflect.Kind{
+		"created_by": reflect.String,
+		"visits":     reflect.Int,
+	}
+	newBuilder := func() *UserBuilder {
+		return NewUserBuilder().
+			WithName("John Doe").
+			WithEmail("john@example.com").
+			WithMetadataSchema(schema)
+	}
+
+	// Valid metadata
+	valid := newBuilder().WithMetadata("created_by", "test").WithMetadata("visits", 3)
+	if _, ok := valid.Build().(*TestUser); !ok {
+		t.Error("Expected build to succeed with conforming metadata")
+	}
+
+	// Missing key
+	result := newBuilder().WithMetadata("created_by", "test").Build()
+	err, isError := result.(error)
+	if !isError {
+		t.Fatal("Expected error for missing metadata key")
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "metadata.visits" {
+		t.Errorf("Expected FieldError for metadata.visits, got %v", err)
+	}
+
+	// Wrong kind
+	result = newBuilder().WithMetadata("created_by", 42).WithMetadata("visits", 3).Build()
+	err, isError = result.(error)
+	if !isError {
+		t.Fatal("Expected error for wrong metadata kind")
+	}
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "metadata.created_by" {
+		t.Errorf("Expected FieldError for metadata.created_by, got %v", err)
+	}
+
+	// Schema is ignored when validation is disabled
+	disabled := newBuilder()
+	disabled.WithValidation(false)
+	if _, ok := disabled.Build().(*TestUser); !ok {
+		t.Error("Expected schema to be skipped when validation is disabled")
+	}
+}
+
+func TestUserBuilder_WithUserRef(t *testing.T) {
+	userA := NewUserBuilder().WithID(42).WithName("User A").WithEmail("a@example.com")
+	userB := NewUserBuilder().WithID(43).WithName("User B").WithEmail("b@example.com").
+		WithUserRef("manager_id", userA)
+
+	user, ok := userB.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user B to build successfully")
+	}
+	if user.Metadata["manager_id"] != 42 {
+		t.Errorf("Expected manager_id to be 42, got %v", user.Metadata["manager_id"])
+	}
+
+	// Cyclic references are detected
+	userA.WithUserRef("report_id", userB)
+	result := userB.Build()
+	err, isError := result.(error)
+	if !isError {
+		t.Fatal("Expected error for cyclic reference")
+	}
+	if !errors.Is(err, ErrCyclicReference) {
+		t.Errorf("Expected ErrCyclicReference, got %v", err)
+	}
+
+	// Nil references are rejected
+	if !NewUserBuilder().WithUserRef("ref", nil).HasErrors() {
+		t.Error("Expected error for nil reference")
+	}
+}
+
+func TestUserBuilder_Template(t *testing.T) {
+	builder := NewUserBuilder().
+		WithName("Template User").
+		WithEmail("template@example.com").
+		WithUserTag("role", "admin").
+		CaptureTemplate()
+
+	// Mutate the builder after capturing
+	builder.WithName("Tweaked User").WithUserTag("role", "guest").WithMetadata("extra", true)
+	builder.WithID(-1)
+
+	result := builder.ResetToTemplate()
+	if result != builder {
+		t.Error("ResetToTemplate should return the same builder instance")
+	}
+	if builder.HasErrors() {
+		t.Error("Expected errors to be cleared after reset to template")
+	}
+
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected template user to build")
+	}
+	if user.Name != "Template User" || user.Tags["role"] != "admin" {
+		t.Errorf("Expected template state to be restored, got %+v", user)
+	}
+	if _, exists := user.Metadata["extra"]; exists {
+		t.Error("Expected metadata added after capture to be discarded")
+	}
+
+	// Restored state is a deep copy, so the template survives further mutation
+	builder.WithUserTag("role", "guest").ResetToTemplate()
+	if builder.user.Tags["role"] != "admin" {
+		t.Error("Expected template to be unaffected by mutations of a restored state")
+	}
+
+	// Without a template an error is recorded
+	if !NewUserBuilder().ResetToTemplate().HasErrors() {
+		t.Error("Expected error when no template was captured")
+	}
+
+	// Frozen and consumed builders can be neither captured nor reset
+	frozen := NewUserBuilder().WithName("Template User").CaptureTemplate()
+	frozen.Freeze()
+	frozen.ResetToTemplate()
+	if !errors.Is(errors.Join(frozen.GetErrors()...), ErrBuilderFrozen) {
+		t.Errorf("Expected ErrBuilderFrozen to be kept, got %v", frozen.GetErrors())
+	}
+	consumed := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com")
+	consumed.GuardAfterBuild()
+	consumed.Build()
+	if consumed.CaptureTemplate(); consumed.template != nil {
+		t.Error("Expected a consumed builder not to capture a template")
+	}
+}
+
+func TestUserBuilder_SetErrorFormatter(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.SetErrorFormatter(func(field, rule string, value any) string {
+		return fmt.Sprintf("[%s] %s violated (%v)", field, rule, value)
+	})
+
+	builder.WithAge(-3)
+	errs := builder.GetErrors()
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(errs))
+	}
+	if errs[0].Error() != "[age] non_negative violated (-3)" {
+		t.Errorf("Expected custom formatted message, got %q", errs[0].Error())
+	}
+
+	// Final validation at build time also uses the formatter
+	builder = NewUserBuilder().WithName("John Doe")
+	builder.SetErrorFormatter(func(field, rule string, _ any) string {
+		return field + "/" + rule
+	})
+	err, isError := builder.Build().(error)
+	if !isError || err.Error() != "email/required" {
+		t.Errorf("Expected custom formatted build error, got %v", err)
+	}
+
+	// The default formatter keeps the English messages
+	builder = NewUserBuilder()
+	builder.WithAge(-1)
+	if builder.GetErrors()[0].Error() != "user age must be non-negative" {
+		t.Errorf("Expected default message, got %q", builder.GetErrors()[0].Error())
+	}
+}
+
+type contactUserBuilder struct {
+	*UserBuilder
+}
+
+func (b *contactUserBuilder) WithPhoneNumber(phone string) *contactUserBuilder {
+	b.MutateUser(func(u *TestUser) {
+		u.Metadata["phone_number"] = phone
+	})
+	return b
+}
+
+func TestUserBuilder_MutateUser(t *testing.T) {
+	builder := &contactUserBuilder{UserBuilder: NewUserBuilder()}
+	builder.WithName("John Doe").WithEmail("john@example.com")
+	builder.WithPhoneNumber("+1-555-0100")
+
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user to build successfully")
+	}
+	if user.Metadata["phone_number"] != "+1-555-0100" {
+		t.Errorf("Expected phone number in metadata, got %v", user.Metadata["phone_number"])
+	}
+
+	// Mutations are rejected once the builder is frozen
+	builder.Freeze()
+	builder.WithPhoneNumber("+1-555-0199")
+	if builder.user.Metadata["phone_number"] != "+1-555-0100" {
+		t.Error("Expected MutateUser to be a no-op on a frozen builder")
+	}
+}
+
+func TestUserBuilder_UpdateMetadata(t *testing.T) {
+	increment := func(v any) any {
+		n, _ := v.(int)
+		return n + 1
+	}
+
+	builder := NewUserBuilder()
+
+	// Absent key starts from nil
+	result := builder.UpdateMetadata("visits", increment)
+	if result != builder {
+		t.Error("UpdateMetadata should return the same builder instance")
+	}
+	if builder.user.Metadata["visits"] != 1 {
+		t.Errorf("Expected visits to be 1, got %v", builder.user.Metadata["visits"])
+	}
+
+	// Present key is incremented
+	builder.UpdateMetadata("visits", increment).UpdateMetadata("visits", increment)
+	if builder.user.Metadata["visits"] != 3 {
+		t.Errorf("Expected visits to be 3, got %v", builder.user.Metadata["visits"])
+	}
+}
+
+func TestUserBuilder_WithBirthdate(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC))
+	birthdate := time.Date(1990, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	builder := NewUserBuilder().WithBirthdate(birthdate, clock)
+	if builder.HasErrors() {
+		t.Fatalf("Expected no errors, got %v", builder.GetErrors())
+	}
+	if builder.user.Age != 35 {
+		t.Errorf("Expected age 35 the day before the birthday, got %d", builder.user.Age)
+	}
+	if builder.user.Metadata[BirthdateMetadataKey] != birthdate {
+		t.Error("Expected birthdate to be stored in metadata")
+	}
+
+	// Age is recomputed when called again after the clock advances
+	clock.Advance(24 * time.Hour)
+	builder.WithBirthdate(birthdate, clock)
+	if builder.user.Age != 36 {
+		t.Errorf("Expected age 36 on the birthday, got %d", builder.user.Age)
+	}
+
+	// Future birthdates are rejected when validation is enabled
+	future := NewUserBuilder().WithBirthdate(clock.Now().AddDate(1, 0, 0), clock)
+	if !future.HasErrors() {
+		t.Error("Expected error for a birthdate in the future")
+	}
+}
+
+func TestUserBuilder_SentinelErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *UserBuilder
+		sentinel error
+	}{
+		{name: "negative ID", builder: NewUserBuilder().WithID(-1), sentinel: ErrNegativeID},
+		{name: "empty name", builder: NewUserBuilder().WithName(""), sentinel: ErrNameRequired},
+		{name: "empty email", builder: NewUserBuilder().WithEmail(""), sentinel: ErrEmailRequired},
+		{name: "negative age", builder: NewUserBuilder().WithAge(-1), sentinel: ErrNegativeAge},
+		{name: "missing name", builder: NewUserBuilder().WithEmail("john@example.com"), sentinel: ErrNameRequired},
+		{name: "missing email", builder: NewUserBuilder().WithName("John Doe"), sentinel: ErrEmailRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, isError := tt.builder.Build().(error)
+			if !isError {
+				t.Fatal("Expected build error")
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("Expected errors.Is(%v, %v) to be true", err, tt.sentinel)
+			}
+		})
+	}
+
+	// Multiple failures are aggregated
+	builder := NewUserBuilder().WithID(-1).WithAge(-1)
+	err, _ := builder.Build().(error)
+	if !errors.Is(err, ErrNegativeID) || !errors.Is(err, ErrNegativeAge) {
+		t.Errorf("Expected both sentinels in aggregated error, got %v", err)
+	}
+	err, _ = NewUserBuilder().Build().(error)
+	if !errors.Is(err, ErrNameRequired) || !errors.Is(err, ErrEmailRequired) {
+		t.Errorf("Expected both required-field sentinels, got %v", err)
+	}
+}
+
+func TestUserBuilder_ValidationContext(t *testing.T) {
+	rejectReserved := func(b Builder, ctx map[string]any) error {
+		userBuilder, ok := b.(*UserBuilder)
+		if !ok {
+			return fmt.Errorf("unexpected builder %T", b)
+		}
+		user, _ := userBuilder.ReadOnly().Preview().(*TestUser)
+		reserved, _ := ctx["reserved"].([]string)
+		if slices.Contains(reserved, user.Name) {
+			return fmt.Errorf("name '%s' is reserved", user.Name)
+		}
+		return nil
+	}
+
+	builder := NewUserBuilder().WithName("admin").WithEmail("admin@example.com")
+	builder.AddValidator("reserved_names", rejectReserved)
+	builder.SetValidationContext(map[string]any{"reserved": []string{"admin", "root"}})
+
+	err, isError := builder.Build().(error)
+	if !isError {
+		t.Fatal("Expected reserved name to be rejected")
+	}
+	if !strings.Contains(err.Error(), "reserved_names") {
+		t.Errorf("Expected error to name the validator, got %v", err)
+	}
+
+	builder.WithName("alice")
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected non-reserved name to pass")
+	}
+
+	// Validators are skipped when validation is disabled
+	builder.WithName("root").WithValidation(false)
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected validators to be skipped when validation is disabled")
+	}
+}
+
+func TestUserBuilder_WithCompositeKey(t *testing.T) {
+	newBuilder := func() *UserBuilder {
+		return NewUserBuilder().
+			WithName("Jane").
+			WithEmail("jane@example.com").
+			WithMetadata("tenant", "acme").
+			WithCompositeKey("name", "email", "metadata.tenant")
+	}
+
+	user, ok := newBuilder().Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected build to succeed")
+	}
+	if key := user.CompositeKey(); key != "Jane|jane@example.com|acme" {
+		t.Errorf("Expected composite key 'Jane|jane@example.com|acme', got %q", key)
+	}
+
+	again, _ := newBuilder().Build().(*TestUser)
+	if again.CompositeKey() != user.CompositeKey() {
+		t.Error("Expected composite key to be stable across builds")
+	}
+
+	if (&TestUser{}).CompositeKey() != "" {
+		t.Error("Expected empty composite key when none was stored")
+	}
+
+	builder := NewUserBuilder().WithCompositeKey("name", "nickname")
+	if !builder.HasErrors() {
+		t.Error("Expected an error for an unsupported composite key field")
+	}
+}
+
+type selfCheckingAddress struct {
+	city string
+}
+
+func (a selfCheckingAddress) Validate() error {
+	if a.city == "" {
+		return errors.New("city is required")
+	}
+	return nil
+}
+
+func TestUserBuilder_ValidatableMetadata(t *testing.T) {
+	builder := NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithMetadata("address", selfCheckingAddress{})
+
+	err, isError := builder.Build().(error)
+	if !isError {
+		t.Fatal("Expected build to fail for an invalid metadata value")
+	}
+	if !strings.Contains(err.Error(), "metadata 'address': city is required") {
+		t.Errorf("Expected metadata validation error, got %v", err)
+	}
+
+	builder.WithMetadata("address", selfCheckingAddress{city: "Lisbon"})
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected build to succeed for a valid metadata value")
+	}
+
+	builder.WithMetadata("address", selfCheckingAddress{}).WithValidation(false)
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected metadata values not to be validated when validation is disabled")
+	}
+}
+
+func TestUserBuilder_WithDefaultsFromEnv(t *testing.T) {
+	t.Setenv("FIXTURE_NAME", "Env User")
+	t.Setenv("FIXTURE_EMAIL", "env@example.com")
+	t.Setenv("FIXTURE_AGE", "42")
+	t.Setenv("FIXTURE_ACTIVE", "true")
+
+	builder := NewUserBuilder().WithID(3).WithDefaultsFromEnv("FIXTURE_")
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatalf("Expected build to succeed, got %v", builder.Build())
+	}
+	if user.Name != "Env User" || user.Email != "env@example.com" || user.Age != 42 || !user.Active {
+		t.Errorf("Expected fields from the environment, got %+v", user)
+	}
+	if user.ID != 3 {
+		t.Error("Expected fields without variables to be left untouched")
+	}
+
+	partial := NewUserBuilder().WithName("Kept").WithDefaultsFromEnv("MISSING_PREFIX_")
+	if partial.user.Name != "Kept" || partial.IsFieldSet("email") {
+		t.Error("Expected no fields to change when no variables exist")
+	}
+}
+
+func TestUserBuilder_WithDefaultsFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv("BAD_AGE", "forty")
+	t.Setenv("BAD_ACTIVE", "maybe")
+
+	builder := NewUserBuilder().WithDefaultsFromEnv("BAD_")
+	errs := builder.GetErrors()
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	var fieldErr *FieldError
+	if !errors.As(errs[0], &fieldErr) || fieldErr.Field != "age" {
+		t.Errorf("Expected age field error, got %v", errs[0])
+	}
+	if !errors.As(errs[1], &fieldErr) || fieldErr.Field != "active" {
+		t.Errorf("Expected active field error, got %v", errs[1])
+	}
+}
+
+func TestUserBuilder_WithMinimalUser(t *testing.T) {
+	user, ok := NewUserBuilder().WithMinimalUser().Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected a minimal user to build successfully")
+	}
+	if user.Name != "User" || user.Email != "user@example.test" {
+		t.Errorf("Expected placeholder name and email, got %q and %q", user.Name, user.Email)
+	}
+
+	user, _ = NewUserBuilder().WithName("Jane").WithMinimalUser().Build().(*TestUser)
+	if user.Name != "Jane" || user.Email != "user@example.test" {
+		t.Errorf("Expected explicitly set fields to be kept, got %q and %q", user.Name, user.Email)
+	}
+}
+
+func TestUserBuilder_ValidationReport(t *testing.T) {
+	builder := NewUserBuilder().WithAge(-1).WithMetadataSchema(map[string]reflect.Kind{"team": reflect.String})
+	builder.WithTag("env", "ci")
+	builder.AddWarning(errors.New("age was not provided"))
+
+	report := builder.ValidationReport()
+	if report.Valid {
+		t.Fatal("Expected report to be invalid")
+	}
+
+	fields := make([]string, 0, len(report.FieldErrors))
+	for _, fieldErr := range report.FieldErrors {
+		fields = append(fields, fieldErr.Field)
+	}
+	expected := []string{"age", "name", "email", "metadata.team"}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("Expected field errors for %v, got %v", expected, fields)
+	}
+	if len(report.Warnings) != 1 {
+		t.Errorf("Expected 1 warning, got %v", report.Warnings)
+	}
+	if report.Tags["env"] != "ci" {
+		t.Errorf("Expected report to include builder tags, got %v", report.Tags)
+	}
+	if builder.BuildCount() != 0 {
+		t.Error("Expected ValidationReport not to build the user")
+	}
+
+	valid := NewUserBuilder().WithName("Jane").WithEmailFrom(NewRoundRobin("jane@example.com")).ValidationReport()
+	if !valid.Valid || len(valid.FieldErrors) != 0 {
+		t.Errorf("Expected a valid report, got %+v", valid.FieldErrors)
+	}
+}
+
+func TestUserBuilder_BuildPatch(t *testing.T) {
+	patch, err := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com").WithAge(0).BuildPatch()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, exists := patch["age"]; exists {
+		t.Error("Expected zero age to be omitted without WithAgeOptional")
+	}
+	if patch["name"] != "Jane" || patch["email"] != "jane@example.com" {
+		t.Errorf("Expected name and email in patch, got %v", patch)
+	}
+
+	if _, err = NewUserBuilder().BuildPatch(); !errors.Is(err, ErrNameRequired) {
+		t.Errorf("Expected build error, got %v", err)
+	}
+}
+
+func TestUserBuilder_WithAgeOptional(t *testing.T) {
+	newBuilder := func() *UserBuilder {
+		return NewUserBuilder().
+			WithName("Jane").
+			WithEmail("jane@example.com").
+			WithStructValidation(true).
+			WithAgeOptional()
+	}
+
+	explicit, err := newBuilder().WithAge(0).BuildPatch()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if age, exists := explicit["age"]; !exists || age != 0 {
+		t.Errorf("Expected explicit zero age in patch, got %v", explicit)
+	}
+
+	unset, err := newBuilder().BuildPatch()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, exists := unset["age"]; exists {
+		t.Errorf("Expected unset age to be omitted from patch, got %v", unset)
+	}
+}
+
+func TestUserBuilder_WithMaxNameLength(t *testing.T) {
+	builder := NewUserBuilder().WithMaxNameLength(5, false).WithName("Alexander")
+	if builder.user.Name != "Alexa" {
+		t.Errorf("Expected name truncated to 'Alexa', got %q", builder.user.Name)
+	}
+	if !builder.HasWarnings() || builder.HasErrors() {
+		t.Error("Expected truncation to record a warning, not an error")
+	}
+
+	multiByte := NewUserBuilder().WithMaxNameLength(3, false).WithName("Zoë Ångström")
+	if multiByte.user.Name != "Zoë" {
+		t.Errorf("Expected truncation on rune boundaries, got %q", multiByte.user.Name)
+	}
+
+	short := NewUserBuilder().WithMaxNameLength(10, false).WithName("Zoë")
+	if short.user.Name != "Zoë" || short.HasWarnings() {
+		t.Error("Expected names within the limit to be kept unchanged")
+	}
+
+	strict := NewUserBuilder().WithMaxNameLength(5, true).WithName("Alexander")
+	if !strict.HasErrors() {
+		t.Error("Expected an error in strict mode")
+	}
+	if strict.IsFieldSet("name") {
+		t.Error("Expected the name not to be set in strict mode")
+	}
+}
+
+func TestUserBuilder_WithChildren(t *testing.T) {
+	children := make([]Builder, 0, 3)
+	for i := range 3 {
+		children = append(children, NewUserBuilder().WithID(i+1).WithName("Child").WithEmail("child@example.com"))
+	}
+
+	user, ok := NewUserBuilder().
+		WithName("Parent").
+		WithEmail("parent@example.com").
+		WithChildren("sessions", children...).
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected build to succeed")
+	}
+
+	sessions, ok := user.Metadata["sessions"].([]any)
+	if !ok || len(sessions) != 3 {
+		t.Fatalf("Expected 3 built children, got %v", user.Metadata["sessions"])
+	}
+	for i, session := range sessions {
+		if child, isUser := session.(*TestUser); !isUser || child.ID != i+1 {
+			t.Errorf("Expected child %d to be built in order, got %v", i, session)
+		}
+	}
+
+	failing := NewUserBuilder().
+		WithName("Parent").
+		WithEmail("parent@example.com").
+		WithChildren("sessions", children[0], NewUserBuilder())
+	err, isError := failing.Build().(error)
+	if !isError || !strings.Contains(err.Error(), "child 1 of 'sessions'") {
+		t.Errorf("Expected error naming the failing child index, got %v", err)
+	}
+}
+
+func TestUserBuilder_WithDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	deadline := clock.Now().Add(time.Hour)
+
+	user, ok := NewUserBuilder().
+		WithName("Session User").
+		WithEmail("session@example.com").
+		WithDeadline(deadline, clock).
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected build to succeed")
+	}
+	if user.IsExpired(clock) {
+		t.Error("Expected user not to be expired before the deadline")
+	}
+
+	clock.Advance(59 * time.Minute)
+	if user.IsExpired(clock) {
+		t.Error("Expected user not to be expired one minute before the deadline")
+	}
+
+	clock.Advance(time.Minute)
+	if !user.IsExpired(clock) {
+		t.Error("Expected user to be expired at the deadline")
+	}
+
+	if (&TestUser{}).IsExpired(clock) {
+		t.Error("Expected users without a deadline never to expire")
+	}
+
+	past := NewUserBuilder().WithDeadline(clock.Now().Add(-time.Hour), clock)
+	if !past.HasWarnings() {
+		t.Error("Expected a warning for a deadline already passed")
+	}
+
+	// Inside a namespace, the deadline and group keys stay unprefixed
+	namespaced := NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithMetadataNamespace("session").
+		WithDeadline(clock.Now().Add(-time.Minute), clock).
+		WithGroup("A").
+		BuildT(t)
+	if !namespaced.IsExpired(clock) {
+		t.Error("Expected a namespaced builder's past deadline to be found by IsExpired")
+	}
+	if groups := GroupByGroup([]*TestUser{namespaced}); len(groups["A"]) != 1 {
+		t.Errorf("Expected GroupByGroup to find the namespaced builder's group, got %v", groups)
+	}
+}
+
+func TestUserBuilder_WithValidationProfile(t *testing.T) {
+	newBuilder := func() *UserBuilder {
+		return NewUserBuilder().WithName("Jane").WithEmail("not-an-email")
+	}
+
+	if _, ok := newBuilder().Build().(*TestUser); !ok {
+		t.Error("Expected the built-in rules to accept the user")
+	}
+
+	strict := newBuilder()
+	strict.WithValidationProfile("strict")
+	err, isError := strict.Build().(error)
+	if !isError {
+		t.Fatal("Expected the strict profile to reject the user")
+	}
+	if !strings.Contains(err.Error(), "email: must be a valid email address") ||
+		!strings.Contains(err.Error(), "age: must be positive") {
+		t.Errorf("Expected strict email and age errors, got %v", err)
+	}
+
+	nameOnly := NewUserBuilder().WithName("Jane")
+	nameOnly.WithValidationProfile("relaxed")
+	if _, ok := nameOnly.Build().(*TestUser); !ok {
+		t.Error("Expected the relaxed profile to accept a user without email")
+	}
+	nameOnly.WithValidationProfile("")
+	if _, ok := nameOnly.Build().(error); !ok {
+		t.Error("Expected clearing the profile to restore the built-in rules")
+	}
+
+	// Rules see the assembled user, with generated and repaired fields
+	generated := NewUserBuilder().WithName("Jane").WithAge(30).WithEmailProvider(CorporateEmailProvider("acme.test"))
+	generated.WithValidationProfile("strict")
+	if result, ok := generated.Build().(error); ok {
+		t.Errorf("Expected the strict profile to accept a generated email, got %v", result)
+	}
+	repaired := newBuilder().WithAge(30).WithRepair(func(user *TestUser) bool {
+		user.Email = "jane@example.com"
+		return true
+	})
+	repaired.WithValidationProfile("strict")
+	if result, ok := repaired.Build().(error); ok {
+		t.Errorf("Expected the strict profile to accept a repaired email, got %v", result)
+	}
+
+	unknown := newBuilder()
+	unknown.WithValidationProfile("missing")
+	if err, isError = unknown.Build().(error); !isError || !strings.Contains(err.Error(), "'missing' is not registered") {
+		t.Errorf("Expected unknown profile error, got %v", err)
+	}
+}
+
+func TestRegisterValidationProfile(t *testing.T) {
+	if err := RegisterValidationProfile("", nil); err == nil {
+		t.Error("Expected error for an empty profile name")
+	}
+
+	err := RegisterValidationProfile("no-admins", []func(Builder) error{
+		userRule(func(user *TestUser) error {
+			if user.Name == "admin" {
+				return errors.New("reserved name")
+			}
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	builder := NewUserBuilder().WithName("admin")
+	builder.WithValidationProfile("no-admins")
+	if _, isError := builder.Build().(error); !isError {
+		t.Error("Expected the custom profile to reject the reserved name")
+	}
+}
+
+func TestUserBuilder_BuildMemoized(t *testing.T) {
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+
+	first, _ := builder.BuildMemoized().(*TestUser)
+	second, _ := builder.BuildMemoized().(*TestUser)
+	if first == nil || second == nil {
+		t.Fatal("Expected memoized builds to succeed")
+	}
+	if builder.BuildCount() != 1 {
+		t.Errorf("Expected the user to be assembled once, got %d builds", builder.BuildCount())
+	}
+	if first == second {
+		t.Error("Expected distinct copies from each call")
+	}
+
+	second.Metadata["corrupted"] = true
+	third, _ := builder.BuildMemoized().(*TestUser)
+	if _, exists := third.Metadata["corrupted"]; exists {
+		t.Error("Expected callers not to corrupt the cache")
+	}
+
+	builder.WithName("Janet")
+	renamed, _ := builder.BuildMemoized().(*TestUser)
+	if renamed.Name != "Janet" || builder.BuildCount() != 2 {
+		t.Error("Expected a mutation to invalidate the cache")
+	}
+
+	builder.Reset()
+	if _, isError := builder.BuildMemoized().(error); !isError {
+		t.Error("Expected Reset to invalidate the cache")
+	}
+}
+
+func TestUserBuilder_BuildMemoizedWithPool(t *testing.T) {
+	assemblies := 0
+	builder := NewUserBuilder().
+		WithNameFromPool([]string{"Jane", "John", "Alex"}, nil).
+		WithEmail("user@example.com").
+		WithLazyMetadata("assembly", func(*TestUser) any {
+			assemblies++
+			return assemblies
+		})
+
+	first, _ := builder.BuildMemoized().(*TestUser)
+	for range 2 {
+		if user, _ := builder.BuildMemoized().(*TestUser); user == nil || user.Name != first.Name {
+			t.Errorf("Expected the memoized user %v, got %v", first, user)
+		}
+	}
+	if assemblies != 1 {
+		t.Errorf("Expected the user to be assembled once, got %d assemblies", assemblies)
+	}
+}
+
+func TestUserBuilder_BuildMemoizedInvalidation(t *testing.T) {
+	builder := NewUserBuilder().WithName("A").WithEmail("a@example.com").CaptureTemplate()
+	builder.WithName("B")
+	if user, _ := builder.BuildMemoized().(*TestUser); user == nil || user.Name != "B" {
+		t.Fatalf("Expected memoized user B, got %v", user)
+	}
+
+	builder.ResetToTemplate()
+	memoized, _ := builder.BuildMemoized().(*TestUser)
+	built, _ := builder.Build().(*TestUser)
+	if memoized == nil || built == nil || memoized.Name != "A" || built.Name != "A" {
+		t.Errorf("Expected ResetToTemplate to invalidate the cache, got %v and %v", memoized, built)
+	}
+}
+
+func TestUserBuilder_BuildWithRetry(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.Seed(7)
+	builder.Randomize()
+	attempts := 0
+	builder.AddValidator("senior", func(b Builder, _ map[string]any) error {
+		attempts++
+		if user := b.(*UserBuilder).user; user.Age < 50 {
+			return &FieldError{Field: "age", Message: "must be at least 50"}
+		}
+		return nil
+	})
+	name := builder.user.Name
+
+	result, err := builder.BuildWithRetry(20)
+	if err != nil {
+		t.Fatalf("Expected eventual success, got %v", err)
+	}
+	user, _ := result.(*TestUser)
+	if user.Age < 50 || attempts < 2 {
+		t.Errorf("Expected a re-randomized age of at least 50 after retries, got %d in %d attempts", user.Age, attempts)
+	}
+	if user.Name != name {
+		t.Errorf("Expected only the offending field to be re-randomized, name changed to %q", user.Name)
+	}
+
+	// Explicitly set fields are never re-randomized
+	explicit := NewUserBuilder()
+	explicit.Seed(7)
+	explicit.Randomize().WithAge(20)
+	attempts = 0
+	explicit.AddValidator("senior", func(b Builder, _ map[string]any) error {
+		attempts++
+		if b.(*UserBuilder).user.Age < 50 {
+			return &FieldError{Field: "age", Message: "must be at least 50"}
+		}
+		return nil
+	})
+	if _, err = explicit.BuildWithRetry(20); err == nil {
+		t.Error("Expected an explicitly set age not to be re-randomized")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected no retries when no offending field is random, got %d attempts", attempts)
+	}
+
+	if _, err = NewUserBuilder().BuildWithRetry(0); err == nil {
+		t.Error("Expected error for zero attempts")
+	}
+}
+
+type mapContainer map[string]any
+
+func (c mapContainer) Resolve(key string) (any, bool) {
+	value, exists := c[key]
+	return value, exists
+}
+
+func TestUserBuilder_WithResolvedName(t *testing.T) {
+	container := mapContainer{"admin.name": "Ada Admin", "admin.id": 1}
+
+	builder := NewUserBuilder().WithResolvedName(container, "admin.name")
+	if builder.HasErrors() || builder.user.Name != "Ada Admin" {
+		t.Errorf("Expected name resolved from the container, got %q (%v)", builder.user.Name, builder.GetErrors())
+	}
+
+	missing := NewUserBuilder().WithResolvedName(container, "guest.name")
+	if !missing.HasErrors() || !strings.Contains(missing.GetErrors()[0].Error(), "'guest.name' not found") {
+		t.Errorf("Expected missing key error, got %v", missing.GetErrors())
+	}
+
+	wrongType := NewUserBuilder().WithResolvedName(container, "admin.id")
+	if !wrongType.HasErrors() || !strings.Contains(wrongType.GetErrors()[0].Error(), "not a string") {
+		t.Errorf("Expected type error, got %v", wrongType.GetErrors())
+	}
+}
+
+type fakeTB struct {
+	testing.TB
+
+	helperCalled bool
+	fatalMessage string
+}
+
+func (f *fakeTB) Helper() { f.helperCalled = true }
+
+func (f *fakeTB) Fatalf(format string, args ...any) { f.fatalMessage = fmt.Sprintf(format, args...) }
+
+func TestUserBuilder_BuildT(t *testing.T) {
+	user := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com").BuildT(t)
+	if user.Name != "Jane" {
+		t.Errorf("Expected the built user, got %+v", user)
+	}
+
+	tb := &fakeTB{}
+	if user = NewUserBuilder().WithName("Jane").BuildT(tb); user != nil {
+		t.Errorf("Expected nil user on failure, got %+v", user)
+	}
+	if !tb.helperCalled {
+		t.Error("Expected Helper to be called")
+	}
+	if !strings.Contains(tb.fatalMessage, ErrEmailRequired.Error()) {
+		t.Errorf("Expected Fatalf with the build error, got %q", tb.fatalMessage)
+	}
+}
+
+func TestUserBuilder_CloneSeed(t *testing.T) {
+	newClones := func() (*UserBuilder, *UserBuilder) {
+		parent := NewUserBuilder()
+		parent.Seed(42)
+		first, _ := parent.Clone().(*UserBuilder)
+		second, _ := parent.Clone().(*UserBuilder)
+		return first, second
+	}
+
+	first, second := newClones()
+	if first.CloneSeed() == second.CloneSeed() || first.CloneSeed() == 42 {
+		t.Errorf("Expected distinct derived seeds, got %d and %d", first.CloneSeed(), second.CloneSeed())
+	}
+
+	firstUser := first.Randomize().BuildT(t)
+	secondUser := second.Randomize().BuildT(t)
+	if UsersEqualIgnoring(firstUser, secondUser) {
+		t.Error("Expected sibling clones to generate distinct users")
+	}
+
+	replayFirst, replaySecond := newClones()
+	if replayFirst.CloneSeed() != first.CloneSeed() || replaySecond.CloneSeed() != second.CloneSeed() {
+		t.Error("Expected the clone seeds to be reproducible")
+	}
+	if !UsersEqualIgnoring(replayFirst.Randomize().BuildT(t), firstUser) ||
+		!UsersEqualIgnoring(replaySecond.Randomize().BuildT(t), secondUser) {
+		t.Error("Expected the clone tree to generate the same users")
+	}
+
+	if unseeded, _ := NewUserBuilder().Clone().(*UserBuilder); unseeded.CloneSeed() != 0 || unseeded.rng != nil {
+		t.Error("Expected clones of unseeded builders to stay unseeded")
+	}
+}
+
+func TestUserBuilder_ValidationGroups(t *testing.T) {
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+	builder.AddValidatorInGroup("create", "id unset", func(b Builder, _ map[string]any) error {
+		if b.(*UserBuilder).user.ID != 0 {
+			return errors.New("new users must not have an ID")
+		}
+		return nil
+	})
+	builder.AddValidatorInGroup("update", "id set", func(b Builder, _ map[string]any) error {
+		if b.(*UserBuilder).user.ID == 0 {
+			return errors.New("updated users must have an ID")
+		}
+		return nil
+	})
+
+	if err := builder.ValidateGroup("create"); err != nil {
+		t.Errorf("Expected the create group to pass, got %v", err)
+	}
+	if err := builder.ValidateGroup("update"); err == nil || !strings.Contains(err.Error(), "validator 'id set'") {
+		t.Errorf("Expected the update group to fail, got %v", err)
+	}
+
+	builder.WithID(7)
+	if err := builder.ValidateGroup("create"); err == nil {
+		t.Error("Expected the create group to fail once an ID is set")
+	}
+	if err := builder.ValidateGroup("update"); err != nil {
+		t.Errorf("Expected the update group to pass, got %v", err)
+	}
+
+	// Build only runs the default group
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected Build to skip non-default groups")
+	}
+	builder.AddValidator("always fails", func(Builder, map[string]any) error { return errors.New("failed") })
+	if err := builder.ValidateGroup(DefaultValidationGroup); err == nil {
+		t.Error("Expected AddValidator to register in the default group")
+	}
+}
+
+func TestUserBuilder_WithMaxMetadataBytes(t *testing.T) {
+	newBuilder := func(blob string) *UserBuilder {
+		return NewUserBuilder().
+			WithName("Jane").
+			WithEmail("jane@example.com").
+			WithMetadata("blob", blob).
+			WithMaxMetadataBytes(32)
+	}
+
+	// {"blob":"..."} adds 11 bytes around the value
+	if _, ok := newBuilder(strings.Repeat("x", 21)).Build().(*TestUser); !ok {
+		t.Error("Expected metadata within the limit to build")
+	}
+
+	err, isError := newBuilder(strings.Repeat("x", 100)).Build().(error)
+	if !isError {
+		t.Fatal("Expected metadata over the limit to fail")
+	}
+	if !strings.Contains(err.Error(), "size of 111 bytes exceeds the limit of 32 bytes") {
+		t.Errorf("Expected the actual size in the error, got %v", err)
+	}
+
+	unchecked := newBuilder(strings.Repeat("x", 100))
+	unchecked.WithValidation(false)
+	if _, ok := unchecked.Build().(*TestUser); !ok {
+		t.Error("Expected the limit to be skipped when validation is disabled")
+	}
+}
+
+func TestUserBuilder_WithWeightedActive(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.Seed(3)
+
+	const samples = 5_000
+	active := 0
+	for range samples {
+		if builder.WithWeightedActive(0.8, 0.2).user.Active {
+			active++
+		}
+	}
+	if ratio := float64(active) / samples; ratio < 0.77 || ratio > 0.83 {
+		t.Errorf("Expected about 80%% active users, got %.3f", ratio)
+	}
+
+	invalid := NewUserBuilder().WithWeightedActive(0, 0)
+	if !invalid.HasErrors() {
+		t.Error("Expected an error when all weights are zero")
+	}
+}
+
+func TestUserBuilder_WithContentDerivedID(t *testing.T) {
+	build := func(name, email string) *TestUser {
+		return NewUserBuilder().WithName(name).WithEmail(email).WithContentDerivedID().BuildT(t)
+	}
+
+	first := build("Jane", "jane@example.com")
+	again := build("Jane", "jane@example.com")
+	if first.ID <= 0 {
+		t.Errorf("Expected a positive ID, got %d", first.ID)
+	}
+	if first.ID != again.ID {
+		t.Errorf("Expected identical inputs to yield identical IDs, got %d and %d", first.ID, again.ID)
+	}
+
+	if other := build("John", "jane@example.com"); other.ID == first.ID {
+		t.Error("Expected a different name to yield a different ID")
+	}
+	// The separator keeps the boundary between name and email significant
+	if shifted := build("Janej", "ane@example.com"); shifted.ID == first.ID {
+		t.Error("Expected shifted content to yield a different ID")
+	}
+}
+
+func TestUserBuilder_LockMetadataKey(t *testing.T) {
+	builder := NewUserBuilder().
+		WithName("Jane").
+		WithEmail("jane@example.com").
+		WithMetadata("tenant", "acme").
+		LockMetadataKey("tenant")
+	builder.WithMetadata("tenant", "other").UpdateMetadata("tenant", func(any) any { return "updated" })
+	builder.WithMetadata("plan", "pro")
+
+	user := builder.BuildT(t)
+	if user.Metadata["tenant"] != "acme" {
+		t.Errorf("Expected the locked key to keep its value, got %v", user.Metadata["tenant"])
+	}
+	if user.Metadata["plan"] != "pro" {
+		t.Errorf("Expected unlocked keys to be writable, got %v", user.Metadata["plan"])
+	}
+	if len(builder.GetWarnings()) != 2 {
+		t.Errorf("Expected a warning per ignored write, got %v", builder.GetWarnings())
+	}
+
+	clone, _ := builder.Clone().(*UserBuilder)
+	clone.WithMetadata("tenant", "cloned")
+	if clone.BuildT(t).Metadata["tenant"] != "acme" {
+		t.Error("Expected the clone to keep the key locked")
+	}
+
+	// Group and deadline writes go through the same path, so they respect locks and are audited
+	grouped := NewUserBuilder().WithName("John Doe").WithEmail("john@example.com").WithGroup("A")
+	grouped.LockMetadataKey(GroupMetadataKey)
+	grouped.EnableAudit()
+	grouped.WithGroup("B").WithDeadline(time.Now().Add(time.Hour), nil)
+	if grouped.BuildT(t).Metadata[GroupMetadataKey] != "A" || !grouped.HasWarnings() {
+		t.Error("Expected WithGroup to respect the locked key")
+	}
+	if trail := grouped.AuditTrail(); len(trail) != 1 || trail[0].Key != DeadlineMetadataKey {
+		t.Errorf("Expected only the deadline write in the audit trail, got %+v", trail)
+	}
+
+	builder.Reset()
+	builder.WithName("Jane").WithEmail("jane@example.com").WithMetadata("tenant", "fresh")
+	if builder.BuildT(t).Metadata["tenant"] != "fresh" {
+		t.Error("Expected Reset to unlock the key")
+	}
+}
+
+func TestUserBuilder_EnableAudit(t *testing.T) {
+	builder := NewUserBuilder().WithName("Ignored")
+	builder.EnableAudit()
+	builder.WithName("Jane").WithAge(30).WithMetadata("plan", "pro").WithUserTag("role", "admin")
+	builder.WithTag("suite", "billing")
+	builder.WithAge(-1) // rejected mutations are not recorded
+	emails := NewRoundRobin("a@example.com")
+	builder.WithNameFromPool([]string{"Ana", "Bo"}, nil).WithEmailFromPool([]string{"b@example.com"}, nil)
+	builder.WithLazyMetadata("score", func(*TestUser) any { return 1 }).WithEmailFrom(emails)
+	builder.MutateUser(func(u *TestUser) { u.Active = false })
+	builder.WithValidation(false)
+
+	expected := []AuditEntry{
+		{Method: "WithName", Key: "name", Value: "Jane"},
+		{Method: "WithAge", Key: "age", Value: 30},
+		{Method: "WithMetadata", Key: "plan", Value: "pro"},
+		{Method: "WithUserTag", Key: "role", Value: "admin"},
+		{Method: "WithTag", Key: "suite", Value: "billing"},
+		{Method: "WithNameFromPool", Key: "name", Value: []string{"Ana", "Bo"}},
+		{Method: "WithEmailFromPool", Key: "email", Value: []string{"b@example.com"}},
+		{Method: "WithLazyMetadata", Key: "score"},
+		{Method: "WithEmailFrom", Key: "email", Value: emails},
+		{Method: "MutateUser", Key: "user"},
+		{Method: "WithValidation", Key: "validation", Value: false},
+	}
+	trail := builder.AuditTrail()
+	if len(trail) != len(expected) {
+		t.Fatalf("Expected %d audit entries, got %v", len(expected), trail)
+	}
+	for i, entry := range trail {
+		if entry.Method != expected[i].Method || entry.Key != expected[i].Key ||
+			!reflect.DeepEqual(entry.Value, expected[i].Value) {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, expected[i], entry)
+		}
+		if entry.Timestamp.IsZero() || (i > 0 && entry.Timestamp.Before(trail[i-1].Timestamp)) {
+			t.Errorf("Entry %d: expected an ordered timestamp, got %v", i, entry.Timestamp)
+		}
+	}
+
+	clone, _ := builder.Clone().(*UserBuilder)
+	clone.WithEmail("jane@example.com")
+	if len(clone.AuditTrail()) != len(expected)+1 || len(builder.AuditTrail()) != len(expected) {
+		t.Error("Expected the clone to copy the trail and keep recording independently")
+	}
+
+	builder.Reset()
+	builder.WithName("John")
+	if len(builder.AuditTrail()) != 0 {
+		t.Errorf("Expected Reset to clear the trail, got %v", builder.AuditTrail())
+	}
+
+	fixed := time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)
+	SetGlobalClock(NewFakeClock(fixed))
+	t.Cleanup(ResetGlobalClock)
+	clocked := NewUserBuilder()
+	clocked.EnableAudit()
+	clocked.WithAge(41)
+	if trail := clocked.AuditTrail(); len(trail) != 1 || !trail[0].Timestamp.Equal(fixed) {
+		t.Errorf("Expected audit timestamps from the global clock, got %v", trail)
+	}
+
+	if trail := NewUserBuilder().WithName("Jane").AuditTrail(); len(trail) != 0 {
+		t.Errorf("Expected auditing to be disabled by default, got %v", trail)
+	}
+}
+
+func TestUserBuilder_ApplyStructDefaults(t *testing.T) {
+	user := NewUserBuilder().WithName("Jane").WithActive(false).ApplyStructDefaults().BuildT(t)
+	if user.Name != "Jane" || user.Active {
+		t.Errorf("Expected explicitly set fields to be kept, got %+v", user)
+	}
+	if user.Email != "unknown@example.com" {
+		t.Errorf("Expected the tagged email default, got %q", user.Email)
+	}
+
+	defaulted := NewUserBuilder().ApplyStructDefaults().BuildT(t)
+	if defaulted.Name != "unknown" || !defaulted.Active {
+		t.Errorf("Expected tagged defaults for unset fields, got %+v", defaulted)
+	}
+}
+
+func TestUserBuilder_LinkReset(t *testing.T) {
+	ids := NewSequence(1, 1)
+	builder := NewUserBuilder()
+	builder.LinkReset(ids.Reset)
+
+	build := func() int {
+		return builder.WithID(ids.Next()).WithName("Jane").WithEmail("jane@example.com").BuildT(t).ID
+	}
+	if first, second := build(), build(); first != 1 || second != 2 {
+		t.Fatalf("Expected IDs 1 and 2, got %d and %d", first, second)
+	}
+
+	builder.Reset()
+	if id := build(); id != 1 {
+		t.Errorf("Expected IDs to restart after Reset, got %d", id)
+	}
+
+	builder.Reset()
+	if id := build(); id != 1 {
+		t.Errorf("Expected the link to survive Reset, got %d", id)
+	}
+}
+
+func TestUserBuilder_CloneConfig(t *testing.T) {
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com").WithAge(30)
+	builder.WithTag("team", "payments").WithValidation(false)
+	builder.AddValidator("always", func(Builder, map[string]any) error { return nil })
+	builder.AddWarning(errors.New("entity warning"))
+
+	clone, ok := builder.CloneConfig().(*UserBuilder)
+	if !ok {
+		t.Fatal("Expected CloneConfig to return a *UserBuilder")
+	}
+	if clone.GetTag("team") != "payments" || clone.IsValidationEnabled() || len(clone.validators) != 1 {
+		t.Error("Expected the builder configuration to be carried over")
+	}
+	if clone.user.Name != "" || clone.user.Email != "" || clone.user.Age != 0 || len(clone.setFields) != 0 {
+		t.Errorf("Expected zero entity state, got %+v", clone.user)
+	}
+	if clone.HasWarnings() {
+		t.Error("Expected entity warnings not to be carried over")
+	}
+
+	clone.WithTag("team", "billing")
+	if builder.GetTag("team") != "payments" {
+		t.Error("Expected the configuration to be copied, not shared")
+	}
+}
+
+func TestUserBuilder_ExplainErrors(t *testing.T) {
+	builder := NewUserBuilder().WithAge(-1)
+
+	explanations := builder.ExplainErrors()
+	if len(explanations) != 3 {
+		t.Fatalf("Expected explanations for the age, name and email, got %v", explanations)
+	}
+	for i, hint := range []string{"WithAge", "WithName", "WithEmail"} {
+		if !strings.Contains(explanations[i], hint) {
+			t.Errorf("Explanation %d: expected a hint mentioning %s, got %q", i, hint, explanations[i])
+		}
+	}
+
+	builder.ClearErrors()
+	if explanations = builder.WithName("Jane").WithEmail("jane@example.com").ExplainErrors(); len(explanations) != 0 {
+		t.Errorf("Expected no explanations for a valid builder, got %v", explanations)
+	}
+}
+
+func TestUserBuilder_MirrorTagsToMetadata(t *testing.T) {
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com").MirrorTagsToMetadata()
+	builder.WithTag("team", "payments")
+
+	user := builder.BuildT(t)
+	tags, ok := MetadataAs[map[string]string](user, TagsMetadataKey)
+	if !ok || len(tags) != 1 || tags["team"] != "payments" {
+		t.Errorf("Expected the builder tags mirrored into metadata, got %v", user.Metadata[TagsMetadataKey])
+	}
+
+	tags["team"] = "changed"
+	if builder.GetTag("team") != "payments" {
+		t.Error("Expected the mirrored tags to be a copy")
+	}
+}
+
+func TestUserBuilder_MirrorMetadataToTags(t *testing.T) {
+	builder := NewUserBuilder().
+		WithMetadata("tenant", "acme").
+		WithMetadata("seats", 5).
+		MirrorMetadataToTags("tenant", "seats", "missing")
+
+	if builder.GetTag("tenant") != "acme" {
+		t.Errorf("Expected the string metadata mirrored into tags, got %v", builder.GetTags())
+	}
+	if builder.HasTag("seats") || builder.HasTag("missing") {
+		t.Errorf("Expected non-string and missing metadata to be skipped, got %v", builder.GetTags())
+	}
+	if len(builder.GetWarnings()) != 2 {
+		t.Errorf("Expected a warning per skipped key, got %v", builder.GetWarnings())
+	}
+}
+
+func TestUserBuilder_WithEnvironment(t *testing.T) {
+	build := func(env string) any {
+		builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+		builder.WithEnvironment(env)
+		return builder.Build()
+	}
+
+	// The strict ci profile requires a positive age, the relaxed test profile doesn't
+	if _, isError := build("ci").(error); !isError {
+		t.Error("Expected the ci environment to apply strict validation")
+	}
+	if _, isError := build("test").(error); isError {
+		t.Error("Expected the test environment to apply relaxed validation")
+	}
+}
+
+func TestUserBuilder_WithLazyMetadata(t *testing.T) {
+	builder := NewUserBuilder().
+		WithEmail("jane@example.com").
+		WithLazyMetadata("greeting", func(u *TestUser) any { return "Hello, " + u.Name })
+	builder.WithName("Jane") // set after registering, still seen by the lazy value
+
+	if greeting := builder.BuildT(t).Metadata["greeting"]; greeting != "Hello, Jane" {
+		t.Errorf("Expected a greeting derived from the final name, got %v", greeting)
+	}
+
+	clone, _ := builder.Clone().(*UserBuilder)
+	clone.WithName("John")
+	if greeting := clone.BuildT(t).Metadata["greeting"]; greeting != "Hello, John" {
+		t.Errorf("Expected the clone to keep the lazy value, got %v", greeting)
+	}
+
+	builder.Reset()
+	builder.WithName("Jane").WithEmail("jane@example.com")
+	if _, exists := builder.BuildT(t).Metadata["greeting"]; exists {
+		t.Error("Expected Reset to clear lazy values")
+	}
+}
+
+func TestUserBuilder_ValidateAsync(t *testing.T) {
+	const validators = 6
+	const delay = 50 * time.Millisecond
+
+	var ran atomic.Int32
+	builder := NewUserBuilder().WithName("Jane")
+	for i := range validators {
+		builder.AddValidator(fmt.Sprintf("slow-%d", i), func(b Builder, _ map[string]any) error {
+			time.Sleep(delay)
+			ran.Add(1)
+			if _, ok := b.(*UserBuilder); !ok {
+				return fmt.Errorf("unexpected target %T", b)
+			}
+			if i%2 == 0 {
+				return fmt.Errorf("check %d failed", i)
+			}
+			return nil
+		})
+	}
+
+	start := time.Now()
+	err := builder.ValidateAsync(context.Background())
+	if elapsed := time.Since(start); elapsed >= validators*delay {
+		t.Errorf("Expected validators to run concurrently, took %v", elapsed)
+	}
+	if ran.Load() != validators {
+		t.Errorf("Expected all %d validators to run, got %d", validators, ran.Load())
+	}
+	for _, expected := range []string{"check 0 failed", "check 2 failed", "check 4 failed"} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the aggregated error to contain %q, got %v", expected, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = builder.ValidateAsync(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the context error, got %v", err)
+	}
+	if err = NewUserBuilder().ValidateAsync(context.Background()); err != nil {
+		t.Errorf("Expected no error without validators, got %v", err)
+	}
+}
+
+func TestUserBuilder_GuardAfterBuild(t *testing.T) {
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+	builder.GuardAfterBuild()
+	user := builder.BuildT(t)
+
+	builder.WithName("John").WithAge(30)
+	if user.Name != "Jane" || builder.user.Name != "Jane" || builder.user.Age != 0 {
+		t.Error("Expected mutations after Build to be ignored")
+	}
+	errs := builder.GetErrors()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrBuilderConsumed) {
+		t.Errorf("Expected a single ErrBuilderConsumed, got %v", errs)
+	}
+
+	builder.Reset()
+	builder.WithName("John").WithEmail("john@example.com")
+	if builder.BuildT(t).Name != "John" {
+		t.Error("Expected Reset to make the builder usable again")
+	}
+	builder.WithName("Jim")
+	if !errors.Is(errors.Join(builder.GetErrors()...), ErrBuilderConsumed) {
+		t.Error("Expected the guard to stay enabled across Reset")
+	}
+
+	unguarded := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+	unguarded.BuildT(t)
+	if unguarded.WithName("John").HasErrors() {
+		t.Error("Expected unguarded builders to accept mutations after Build")
+	}
+
+	failing := NewUserBuilder().WithName("Jane")
+	failing.GuardAfterBuild()
+	if _, isErr := failing.Build().(error); !isErr {
+		t.Fatal("Expected the build without an email to fail")
+	}
+	failing.WithEmail("jane@example.com")
+	if failing.HasErrors() {
+		t.Errorf("Expected a failed build to leave the builder correctable, got %v", failing.GetErrors())
+	}
+	if failing.BuildT(t).Email != "jane@example.com" || !failing.WithName("John").HasErrors() {
+		t.Error("Expected the successful build to consume the builder")
+	}
+}
+
+func TestUserBuilder_WithNameFromPool(t *testing.T) {
+	names := []string{"Ana", "Bo", "Cy", "Di"}
+	emails := []string{"a@example.com", "b@example.com", "c@example.com"}
+	pick := func() []string {
+		rng := rand.New(rand.NewPCG(9, 9)) //nolint:gosec // deterministic test data
+		builder := NewUserBuilder().WithNameFromPool(names, rng).WithEmailFromPool(emails, rng)
+		var picks []string
+		for range 10 {
+			user := builder.BuildT(t)
+			if !slices.Contains(names, user.Name) || !slices.Contains(emails, user.Email) {
+				t.Fatalf("Expected values from the pools, got %q and %q", user.Name, user.Email)
+			}
+			picks = append(picks, user.Name+" "+user.Email)
+		}
+		return picks
+	}
+
+	first := pick()
+	if !slices.Equal(first, pick()) {
+		t.Error("Expected the same rng seed to reproduce the picks")
+	}
+	if slices.Equal(first, slices.Repeat(first[:1], len(first))) {
+		t.Errorf("Expected varied picks, got %v", first)
+	}
+
+	builder := NewUserBuilder().WithNameFromPool(nil, nil).WithEmailFromPool([]string{}, nil)
+	if len(builder.GetErrors()) != 2 {
+		t.Errorf("Expected an error per empty pool, got %v", builder.GetErrors())
+	}
+}
+
+func TestUserBuilder_DetectMetadataConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		second   any
+		key      string
+		conflict bool
+	}{
+		{name: "same value", key: "plan", second: "pro", conflict: false},
+		{name: "different value", key: "plan", second: "free", conflict: true},
+		{name: "new key", key: "seats", second: 5, conflict: false},
+	}
+
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			builder := NewUserBuilder().DetectMetadataConflicts(strict).WithMetadata("plan", "pro")
+			builder.WithMetadata(tt.key, tt.second)
+
+			reported := builder.GetWarnings()
+			if strict {
+				reported = builder.GetErrors()
+			}
+			if (len(reported) == 1) != tt.conflict {
+				t.Errorf("%s (strict=%v): expected conflict=%v, got %v", tt.name, strict, tt.conflict, reported)
+			}
+			if builder.user.Metadata[tt.key] != tt.second {
+				t.Errorf("%s (strict=%v): expected the new value to be stored", tt.name, strict)
+			}
+		}
+	}
+
+	if builder := NewUserBuilder().WithMetadata("plan", "pro").WithMetadata("plan", "free"); builder.HasWarnings() {
+		t.Error("Expected conflict detection to be disabled by default")
+	}
+}
+
+func TestUserBuilder_WithValidationTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	slow := func(Builder, map[string]any) error {
+		<-release
+		return nil
+	}
+
+	builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+	builder.WithValidationTimeout(20*time.Millisecond).AddValidator("hanging", slow)
+
+	start := time.Now()
+	err, _ := builder.Build().(error)
+	if !errors.Is(err, ErrValidationTimeout) {
+		t.Errorf("Expected ErrValidationTimeout from Build, got %v", err)
+	}
+	if err = builder.ValidateAsync(context.Background()); !errors.Is(err, ErrValidationTimeout) {
+		t.Errorf("Expected ErrValidationTimeout from ValidateAsync, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the timeout to stop waiting, took %v", elapsed)
+	}
+
+	fast := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+	fast.WithValidationTimeout(time.Second).AddValidator("fast", func(Builder, map[string]any) error { return nil })
+	fast.BuildT(t)
+}
+
+func TestUserBuilder_Apply(t *testing.T) {
+	named := func(b *UserBuilder) *UserBuilder { return b.WithName("Jane") }
+	contact := func(b *UserBuilder) *UserBuilder { return b.WithEmail("jane@example.com") }
+	adult := func(b *UserBuilder) *UserBuilder { return b.WithAge(30) }
+
+	user := NewUserBuilder().Apply(named, nil, contact, adult).BuildT(t)
+	if user.Name != "Jane" || user.Email != "jane@example.com" || user.Age != 30 {
+		t.Errorf("Expected the cumulative effect of all transforms, got %+v", user)
+	}
+
+	invalid := func(b *UserBuilder) *UserBuilder { return b.WithAge(-1) }
+	builder := NewUserBuilder().Apply(invalid, named)
+	if builder.user.Name != "Jane" {
+		t.Error("Expected Apply to run every transform despite errors")
+	}
+
+	builder = NewUserBuilder().ApplyUntilError(named, invalid, contact)
+	if builder.user.Name != "Jane" || builder.user.Email != "" {
+		t.Errorf("Expected ApplyUntilError to stop after the failing transform, got %+v", builder.user)
+	}
+}
+
+func TestUserBuilder_WithMutuallyExclusive(t *testing.T) {
+	newBuilder := func() *UserBuilder {
+		return NewUserBuilder().
+			WithName("John Doe").
+			WithEmail("john@example.com").
+			WithMutuallyExclusive("age", "birthdate")
+	}
+	birthdate := time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := newBuilder().WithAge(30).Build().(*TestUser); !ok {
+		t.Error("Expected only age to be allowed")
+	}
+	if _, ok := newBuilder().WithMetadata(BirthdateMetadataKey, birthdate).Build().(*TestUser); !ok {
+		t.Error("Expected only birthdate metadata to be allowed")
+	}
+
+	result := newBuilder().WithAge(30).WithMetadata(BirthdateMetadataKey, birthdate).Build()
+	err, ok := result.(error)
+	if !ok {
+		t.Fatal("Expected error when both exclusive fields are set")
+	}
+	if !strings.Contains(err.Error(), "mutually exclusive fields set together: age, birthdate") {
+		t.Errorf("Expected error naming the conflict, got %v", err)
+	}
+
+	// Metadata keys may be prefixed; clones keep the constraint, and Reset clears it
+	builder := NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithMutuallyExclusive("age", "metadata.birthdate").
+		WithAge(30).
+		WithMetadata(BirthdateMetadataKey, birthdate)
+	if _, ok = builder.Clone().Build().(error); !ok {
+		t.Error("Expected clone to keep the constraint")
+	}
+	builder.Reset()
+	if len(builder.exclusiveFields) != 0 {
+		t.Error("Expected Reset to clear the constraint")
+	}
+}
+
+func TestUserBuilder_WithIDFrom(t *testing.T) {
+	seq := NewJitteredSequence(1, 2, nil)
+	seen := make(map[int]bool)
+	for range 2 {
+		user := NewUserBuilder().WithIDFrom(seq).WithName("Jane").WithEmail("jane@example.com").BuildT(t)
+		seen[user.ID] = true
+	}
+	if !seen[1] || !seen[2] {
+		t.Errorf("Expected IDs 1 and 2 from the sequence, got %v", seen)
+	}
+
+	err, isError := NewUserBuilder().WithIDFrom(seq).WithName("Jane").WithEmail("jane@example.com").Build().(error)
+	if !isError || !errors.Is(err, ErrSequenceExhausted) {
+		t.Errorf("Expected ErrSequenceExhausted as a build error, got %v", err)
+	}
+}
@@ -1,20 +1,49 @@
 package testkit
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
 	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // BuilderFactory provides a way to register and create different types of builders.
 type BuilderFactory struct {
-	builders map[string]func() Builder
+	builders   map[string]func() Builder
+	aliases    map[string]string
+	uniqueness *UniquenessTracker
+	// parent is consulted by Create for names not registered locally
+	parent *BuilderFactory
+
+	// metricsEnabled turns on recording of creation metrics
+	metricsEnabled atomic.Bool
+	metricsMu      sync.Mutex
+	metrics        map[string]*FactoryMetric
+}
+
+// FactoryMetric holds creation statistics for a registered builder.
+type FactoryMetric struct {
+	// Count is the number of builders created
+	Count int
+	// TotalDuration is the time spent in the creation function across all creations
+	TotalDuration time.Duration
+	// AverageDuration is TotalDuration divided by Count
+	AverageDuration time.Duration
 }
 
 // NewBuilderFactory creates a new BuilderFactory instance.
 func NewBuilderFactory() *BuilderFactory {
 	return &BuilderFactory{
-		builders: make(map[string]func() Builder),
+		builders:   make(map[string]func() Builder),
+		aliases:    make(map[string]string),
+		uniqueness: NewUniquenessTracker(),
 	}
 }
 
@@ -26,23 +55,246 @@ func (f *BuilderFactory) Register(name string, createFunc func() Builder) error
 	if createFunc == nil {
 		return errors.New("builder creation function cannot be nil")
 	}
+	if _, isAlias := f.aliases[name]; isAlias {
+		return fmt.Errorf("builder name '%s' conflicts with a registered alias", name)
+	}
 	f.builders[name] = createFunc
 	return nil
 }
 
-// Create creates a new builder instance by name.
+// RegisterMap registers every builder creation function in m.
+// Invalid entries are skipped and reported in a joined error, while valid ones are still registered.
+func (f *BuilderFactory) RegisterMap(m map[string]func() Builder) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(m)) {
+		if err := f.Register(name, m[name]); err != nil {
+			errs = append(errs, fmt.Errorf("cannot register builder '%s': %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterAlias registers an alternative name for an already registered builder.
+func (f *BuilderFactory) RegisterAlias(alias, name string) error {
+	if alias == "" {
+		return errors.New("builder alias cannot be empty")
+	}
+	if _, exists := f.builders[alias]; exists {
+		return fmt.Errorf("alias '%s' conflicts with a registered builder", alias)
+	}
+	if _, exists := f.builders[name]; !exists {
+		return fmt.Errorf("builder '%s' not registered", name)
+	}
+	if f.aliases == nil {
+		f.aliases = make(map[string]string)
+	}
+	f.aliases[alias] = name
+	return nil
+}
+
+// resolve returns the builder name an alias points to, or the name itself.
+func (f *BuilderFactory) resolve(name string) string {
+	if target, isAlias := f.aliases[name]; isAlias {
+		return target
+	}
+	return name
+}
+
+// WithParent sets a factory consulted by Create and IsRegistered for names not registered locally,
+// so team-specific factories can fall back to a shared one. Parents can be chained; a chain looping
+// back on itself is only walked once. A nil parent removes the fallback.
+func (f *BuilderFactory) WithParent(parent *BuilderFactory) *BuilderFactory {
+	f.parent = parent
+	return f
+}
+
+// owner returns the first factory of the parent chain registering name or alias, or nil.
+func (f *BuilderFactory) owner(name string) *BuilderFactory {
+	visited := make(map[*BuilderFactory]bool)
+	for factory := f; factory != nil && !visited[factory]; factory = factory.parent {
+		visited[factory] = true
+		if _, exists := factory.builders[factory.resolve(name)]; exists {
+			return factory
+		}
+	}
+	return nil
+}
+
+// Create creates a new builder instance by name or alias, falling back to the parent factory if any.
+// Metrics are recorded by the factory the builder is registered in.
 func (f *BuilderFactory) Create(name string) (Builder, error) {
-	createFunc, exists := f.builders[name]
+	owner, resolved, err := f.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	createFunc := owner.builders[resolved]
+	if !owner.metricsEnabled.Load() {
+		return createFunc(), nil
+	}
+
+	start := time.Now()
+	builder := createFunc()
+	owner.recordCreation(resolved, time.Since(start))
+	return builder, nil
+}
+
+// lookup returns the factory registering name and the builder name an alias resolves to.
+func (f *BuilderFactory) lookup(name string) (*BuilderFactory, string, error) {
+	owner := f.owner(name)
+	if owner == nil {
+		return nil, "", fmt.Errorf("builder '%s' not registered", name)
+	}
+	return owner, owner.resolve(name), nil
+}
+
+// EnableMetrics starts recording creation counts and durations per builder name, exposed by Metrics.
+// Creations through an alias are recorded under the aliased builder name.
+func (f *BuilderFactory) EnableMetrics() *BuilderFactory {
+	f.metricsEnabled.Store(true)
+	return f
+}
+
+// Metrics returns a snapshot of the creation metrics recorded since EnableMetrics, keyed by builder name.
+func (f *BuilderFactory) Metrics() map[string]FactoryMetric {
+	f.metricsMu.Lock()
+	defer f.metricsMu.Unlock()
+
+	result := make(map[string]FactoryMetric, len(f.metrics))
+	for name, metric := range f.metrics {
+		snapshot := *metric
+		snapshot.AverageDuration = metric.TotalDuration / time.Duration(metric.Count)
+		result[name] = snapshot
+	}
+	return result
+}
+
+// recordCreation adds a creation of the named builder to the metrics.
+func (f *BuilderFactory) recordCreation(name string, duration time.Duration) {
+	f.metricsMu.Lock()
+	defer f.metricsMu.Unlock()
+
+	if f.metrics == nil {
+		f.metrics = make(map[string]*FactoryMetric)
+	}
+	metric, exists := f.metrics[name]
 	if !exists {
-		return nil, fmt.Errorf("builder '%s' not registered", name)
+		metric = &FactoryMetric{}
+		f.metrics[name] = metric
+	}
+	metric.Count++
+	metric.TotalDuration += duration
+}
+
+// CreateSeeded creates a new builder instance by name and seeds it when it implements Seedable.
+// This makes factory-created random fixtures reproducible.
+func (f *BuilderFactory) CreateSeeded(name string, seed int64) (Builder, error) {
+	builder, err := f.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if seedable, ok := builder.(Seedable); ok {
+		seedable.Seed(seed)
+	}
+	return builder, nil
+}
+
+// CreateUnique creates a new builder tagged with tagKey=tagValue,
+// returning an error if that tag value was already used by a previous CreateUnique call.
+// The builder must support tags through a BaseBuilder or a WithTag(key, value string) method;
+// the value is only claimed once the builder was tagged.
+func (f *BuilderFactory) CreateUnique(name, tagKey, tagValue string) (Builder, error) {
+	builder, err := f.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if err = tagBuilder(builder, tagKey, tagValue); err != nil {
+		return nil, fmt.Errorf("builder '%s' %w", name, err)
 	}
-	return createFunc(), nil
+	if err = f.Uniqueness().Claim(tagKey, tagValue); err != nil {
+		return nil, err
+	}
+	return builder, nil
+}
+
+// tagBuilder sets a tag through the builder's BaseBuilder, or else through a WithTag(key, value string) method.
+func tagBuilder(builder Builder, key, value string) error {
+	if accessor, ok := builder.(BaseBuilderAccessor); ok && accessor.Base() != nil {
+		base := accessor.Base()
+		base.WithTag(key, value)
+		if base.GetTag(key) != value || !base.HasTag(key) {
+			return errors.New("rejected the tag")
+		}
+		return nil
+	}
+
+	method := reflect.ValueOf(builder).MethodByName("WithTag")
+	if !method.IsValid() {
+		return errors.New("does not support tags")
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 2 || methodType.In(0).Kind() != reflect.String || methodType.In(1).Kind() != reflect.String {
+		return errors.New("does not support tags: WithTag must take a key and a value string")
+	}
+	method.Call([]reflect.Value{
+		reflect.ValueOf(key).Convert(methodType.In(0)),
+		reflect.ValueOf(value).Convert(methodType.In(1)),
+	})
+	return nil
 }
 
-// IsRegistered checks if a builder is registered with the given name.
+// Uniqueness returns the tracker used by CreateUnique.
+// Call its Reset method to isolate tests sharing a factory.
+func (f *BuilderFactory) Uniqueness() *UniquenessTracker {
+	if f.uniqueness == nil {
+		f.uniqueness = NewUniquenessTracker()
+	}
+	return f.uniqueness
+}
+
+// IsRegistered checks if a builder is registered with the given name or alias, locally or in a parent factory.
 func (f *BuilderFactory) IsRegistered(name string) bool {
-	_, exists := f.builders[name]
-	return exists
+	return f.owner(name) != nil
+}
+
+// TypeOf returns the type of the object built by the named builder.
+// The builder must implement TypedBuilder to declare its output type.
+func (f *BuilderFactory) TypeOf(name string) (reflect.Type, error) {
+	owner, resolved, err := f.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	// Inspecting the type is not a creation, so it bypasses the metrics
+	typed, ok := owner.builders[resolved]().(TypedBuilder)
+	if !ok {
+		return nil, fmt.Errorf("builder '%s' does not declare its output type", name)
+	}
+	return typed.OutputType(), nil
+}
+
+// builderExport describes a registered builder in the JSON export.
+type builderExport struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Aliases []string `json:"aliases"`
+}
+
+// ExportJSON returns a JSON array describing each registered builder, its output type, and its aliases.
+// Entries are sorted by name for stable output; builders not declaring their type have an empty type.
+func (f *BuilderFactory) ExportJSON() ([]byte, error) {
+	exports := make([]builderExport, 0, len(f.builders))
+	for _, name := range slices.Sorted(maps.Keys(f.builders)) {
+		export := builderExport{Name: name, Aliases: make([]string, 0)}
+		if outputType, err := f.TypeOf(name); err == nil {
+			export.Type = outputType.String()
+		}
+		for _, alias := range slices.Sorted(maps.Keys(f.aliases)) {
+			if f.aliases[alias] == name {
+				export.Aliases = append(export.Aliases, alias)
+			}
+		}
+		exports = append(exports, export)
+	}
+	return json.Marshal(exports)
 }
 
 // GetRegisteredNames returns all registered builder names.
@@ -54,6 +306,34 @@ func (f *BuilderFactory) GetRegisteredNames() []string {
 	return names
 }
 
+// FactorySnapshot holds the registrations of a BuilderFactory, captured by Snapshot and reinstated by Restore.
+type FactorySnapshot struct {
+	builders map[string]func() Builder
+	aliases  map[string]string
+	parent   *BuilderFactory
+	claims   map[string]map[string]bool
+}
+
+// Snapshot captures the registered builders, aliases, parent and claimed unique values of the factory.
+// Metrics are not captured.
+func (f *BuilderFactory) Snapshot() FactorySnapshot {
+	return FactorySnapshot{
+		builders: maps.Clone(f.builders),
+		aliases:  maps.Clone(f.aliases),
+		parent:   f.parent,
+		claims:   f.Uniqueness().claims(),
+	}
+}
+
+// Restore reinstates the state captured by Snapshot in place, so holders of the factory see the restored state.
+// Registrations and claims made since the snapshot are discarded.
+func (f *BuilderFactory) Restore(snapshot FactorySnapshot) {
+	f.builders = maps.Clone(snapshot.builders)
+	f.aliases = maps.Clone(snapshot.aliases)
+	f.parent = snapshot.parent
+	f.Uniqueness().restoreClaims(snapshot.claims)
+}
+
 // DefaultFactory is a global factory instance for convenience.
 var DefaultFactory = NewBuilderFactory() //nolint:gochecknoglobals // intentional singleton for convenience API
 
@@ -69,9 +349,12 @@ func CreateBuilder(name string) (Builder, error) {
 
 // BuilderConfig provides configuration options for builders.
 type BuilderConfig struct {
-	ValidationEnabled bool
-	Tags              map[string]string
-	DefaultValues     map[string]any
+	ValidationEnabled bool              `json:"validation_enabled"`
+	Tags              map[string]string `json:"tags"`
+	DefaultValues     map[string]any    `json:"default_values"`
+
+	// rollback restores the builder when ApplyTo fails
+	rollback bool
 }
 
 // NewBuilderConfig creates a new BuilderConfig with default settings.
@@ -107,12 +390,113 @@ func (c *BuilderConfig) WithDefault(key string, value any) *BuilderConfig {
 	return c
 }
 
+// WithRollback makes ApplyTo atomic: the builder is cloned before the configuration is applied,
+// and restored from the clone if applying fails, so a failed ApplyTo leaves the builder untouched.
+// Restoring requires a builder implemented by a struct pointer.
+func (c *BuilderConfig) WithRollback(enabled bool) *BuilderConfig {
+	c.rollback = enabled
+	return c
+}
+
 // ApplyTo applies the configuration to a builder.
 func (c *BuilderConfig) ApplyTo(builder Builder) error {
 	if builder == nil {
 		return errors.New("builder cannot be nil")
 	}
+	if !c.rollback {
+		return c.apply(builder)
+	}
+
+	// Cloning a seeded builder advances its clone seeds, which the snapshot must not disturb
+	restoreSeed := func() {}
+	if seeded, ok := builder.(cloneSeeder); ok {
+		state := seeded.cloneSeedState()
+		restoreSeed = func() { seeded.restoreCloneSeedState(state) }
+	}
+	snapshot := builder.Clone()
+	restoreSeed()
+	if err := c.apply(builder); err != nil {
+		if restoreErr := restoreBuilder(builder, snapshot); restoreErr != nil {
+			return errors.Join(err, restoreErr)
+		}
+		restoreSeed()
+		return err
+	}
+	return nil
+}
+
+// cloneSeeder is implemented by builders whose Clone changes their own random source state.
+type cloneSeeder interface {
+	cloneSeedState() cloneSeedState
+	restoreCloneSeedState(state cloneSeedState)
+}
+
+// cloneSeedState holds the random source state of a builder, from which its clones are seeded.
+type cloneSeedState struct {
+	seed       int64
+	rng        *rand.Rand
+	cloneCount int
+}
+
+// apply applies the configuration to a non-nil builder.
+func (c *BuilderConfig) apply(builder Builder) error {
+	// Prefer direct access to the embedded BaseBuilder, however deeply it is embedded
+	if accessor, ok := builder.(BaseBuilderAccessor); ok && accessor.Base() != nil {
+		base := accessor.Base()
+		base.WithValidation(c.ValidationEnabled)
+		for key, value := range c.Tags {
+			base.WithTag(key, value)
+		}
+	} else {
+		c.applyReflectively(builder)
+	}
+
+	// For more complex default value application, builders should implement
+	// a ConfigurableBuilder interface if they need this functionality
+	if configurableBuilder, ok := builder.(ConfigurableBuilder); ok {
+		return configurableBuilder.ApplyConfig(c)
+	}
+
+	return nil
+}
+
+// restoreBuilder overwrites the state of builder with that of snapshot, a clone taken earlier.
+// The build and reset counters and the frozen and consumed states, which clones don't carry, are kept from builder.
+func restoreBuilder(builder, snapshot Builder) error {
+	target, source := reflect.ValueOf(builder), reflect.ValueOf(snapshot)
+	if target.Kind() != reflect.Pointer || target.IsNil() || source.Type() != target.Type() ||
+		target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot restore builder of type %T", builder)
+	}
+
+	accessor, hasBase := builder.(BaseBuilderAccessor)
+	if !hasBase || accessor.Base() == nil {
+		target.Elem().Set(source.Elem())
+		return nil
+	}
+
+	kept := *accessor.Base()
+	target.Elem().Set(source.Elem())
+	if base := accessor.Base(); base != nil {
+		base.dirty = true
+		base.buildCount = kept.buildCount
+		base.resetCount = kept.resetCount
+		base.frozen = kept.frozen
+		base.frozenErrorRecorded = kept.frozenErrorRecorded
+		if kept.frozenErrorRecorded {
+			base.errors = append(base.errors, ErrBuilderFrozen)
+		}
+		base.consumed = kept.consumed
+		base.consumedErrorRecorded = kept.consumedErrorRecorded
+		if kept.consumedErrorRecorded {
+			base.errors = append(base.errors, ErrBuilderConsumed)
+		}
+	}
+	return nil
+}
 
+// applyReflectively applies validation and tags through WithValidation and WithTag methods found by reflection.
+func (c *BuilderConfig) applyReflectively(builder Builder) {
 	// Use reflection to check if the builder has BaseBuilder methods
 	builderValue := reflect.ValueOf(builder)
 
@@ -129,14 +513,12 @@ func (c *BuilderConfig) ApplyTo(builder Builder) error {
 			}
 		}
 	}
+}
 
-	// For more complex default value application, builders should implement
-	// a ConfigurableBuilder interface if they need this functionality
-	if configurableBuilder, ok := builder.(ConfigurableBuilder); ok {
-		return configurableBuilder.ApplyConfig(c)
-	}
-
-	return nil
+// BaseBuilderAccessor interface for builders exposing their embedded BaseBuilder.
+// BaseBuilder implements it, so any builder embedding it, at any depth, does too.
+type BaseBuilderAccessor interface {
+	Base() *BaseBuilder
 }
 
 // ConfigurableBuilder interface for builders that can accept configuration.
@@ -144,3 +526,82 @@ type ConfigurableBuilder interface {
 	Builder
 	ApplyConfig(config *BuilderConfig) error
 }
+
+// ContextBuilder interface for builders whose build can be cancelled through a context.
+type ContextBuilder interface {
+	Builder
+	BuildContext(ctx context.Context) any
+}
+
+// Container interface for dependency injection registries that builders can pull values from.
+type Container interface {
+	Resolve(key string) (any, bool)
+}
+
+// Seedable interface for builders that generate random data from a seedable source.
+type Seedable interface {
+	Seed(seed int64)
+}
+
+// TypedBuilder interface for builders that declare the type of the object they build.
+type TypedBuilder interface {
+	Builder
+	OutputType() reflect.Type
+}
+
+// UniquenessTracker records tag values that must be unique per tag key.
+// It is safe for concurrent use.
+type UniquenessTracker struct {
+	mu   sync.Mutex
+	used map[string]map[string]bool
+}
+
+// NewUniquenessTracker creates a new UniquenessTracker instance.
+func NewUniquenessTracker() *UniquenessTracker {
+	return &UniquenessTracker{
+		used: make(map[string]map[string]bool),
+	}
+}
+
+// Claim records a tag value, returning an error if it was already claimed for the key.
+func (t *UniquenessTracker) Claim(key, value string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.used[key][value] {
+		return fmt.Errorf("tag '%s' value '%s' is already in use", key, value)
+	}
+	if t.used[key] == nil {
+		t.used[key] = make(map[string]bool)
+	}
+	t.used[key][value] = true
+	return nil
+}
+
+// Reset forgets all claimed values.
+func (t *UniquenessTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.used = make(map[string]map[string]bool)
+}
+
+// claims returns a deep copy of the claimed values.
+func (t *UniquenessTracker) claims() map[string]map[string]bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make(map[string]map[string]bool, len(t.used))
+	for key, values := range t.used {
+		result[key] = maps.Clone(values)
+	}
+	return result
+}
+
+// restoreClaims replaces the claimed values with a deep copy of claims.
+func (t *UniquenessTracker) restoreClaims(claims map[string]map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.used = make(map[string]map[string]bool, len(claims))
+	for key, values := range claims {
+		t.used[key] = maps.Clone(values)
+	}
+}
@@ -1,7 +1,12 @@
 package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
 
 import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestBuilderFactory_NewBuilderFactory(t *testing.T) {
@@ -229,3 +234,459 @@ func TestUserBuilderRegistration(t *testing.T) {
 		t.Error("Expected non-nil UserBuilder")
 	}
 }
+
+func TestBuilderFactory_CreateSeeded(t *testing.T) {
+	factory := NewBuilderFactory()
+	factory.Register("user", createUserBuilder)
+	factory.Register("base", func() Builder { return NewBaseBuilder() })
+
+	build := func(seed int64) *TestUser {
+		builder, err := factory.CreateSeeded("user", seed)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		userBuilder, ok := builder.(*UserBuilder)
+		if !ok {
+			t.Fatalf("Expected *UserBuilder, got %T", builder)
+		}
+		user, ok := userBuilder.Randomize().Build().(*TestUser)
+		if !ok {
+			t.Fatal("Expected random user to build successfully")
+		}
+		return user
+	}
+
+	first := build(42)
+	second := build(42)
+	if first.ID != second.ID || first.Name != second.Name || first.Email != second.Email ||
+		first.Age != second.Age || first.Active != second.Active {
+		t.Errorf("Expected identical users for the same seed, got %+v and %+v", first, second)
+	}
+
+	other := build(7)
+	if first.ID == other.ID && first.Email == other.Email {
+		t.Error("Expected different users for different seeds")
+	}
+
+	// Non-seedable builders are created without error
+	if _, err := factory.CreateSeeded("base", 42); err != nil {
+		t.Errorf("Expected no error for non-seedable builder, got %v", err)
+	}
+
+	if _, err := factory.CreateSeeded("nonexistent", 42); err == nil {
+		t.Error("Expected error for non-existent builder")
+	}
+}
+
+func TestBuilderFactory_RegisterAlias(t *testing.T) {
+	factory := NewBuilderFactory()
+	factory.Register("user", createUserBuilder)
+
+	if err := factory.RegisterAlias("member", "user"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !factory.IsRegistered("member") {
+		t.Error("Expected alias to be registered")
+	}
+	builder, err := factory.Create("member")
+	if err != nil {
+		t.Fatalf("Expected no error creating by alias, got %v", err)
+	}
+	if _, ok := builder.(*UserBuilder); !ok {
+		t.Errorf("Expected *UserBuilder, got %T", builder)
+	}
+
+	if err = factory.RegisterAlias("", "user"); err == nil {
+		t.Error("Expected error for empty alias")
+	}
+	if err = factory.RegisterAlias("user", "user"); err == nil {
+		t.Error("Expected error for alias conflicting with a builder name")
+	}
+	if err = factory.RegisterAlias("ghost", "nonexistent"); err == nil {
+		t.Error("Expected error for alias of unregistered builder")
+	}
+	if err = factory.Register("member", createUserBuilder); err == nil {
+		t.Error("Expected error for builder name conflicting with an alias")
+	}
+}
+
+func TestBuilderFactory_TypeOf(t *testing.T) {
+	factory := NewBuilderFactory()
+	factory.Register("user", createUserBuilder)
+	factory.Register("base", func() Builder { return NewBaseBuilder() })
+
+	outputType, err := factory.TypeOf("user")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if outputType != reflect.TypeFor[*TestUser]() {
+		t.Errorf("Expected *TestUser, got %v", outputType)
+	}
+
+	if _, err = factory.TypeOf("base"); err == nil {
+		t.Error("Expected error for builder without declared type")
+	}
+	if _, err = factory.TypeOf("nonexistent"); err == nil {
+		t.Error("Expected error for non-existent builder")
+	}
+}
+
+func TestBuilderFactory_ExportJSON(t *testing.T) {
+	factory := NewBuilderFactory()
+	factory.Register("user", createUserBuilder)
+	factory.Register("base", func() Builder { return NewBaseBuilder() })
+	factory.RegisterAlias("member", "user")
+	factory.RegisterAlias("account", "user")
+
+	data, err := factory.ExportJSON()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var exported []struct {
+		Name    string   `json:"name"`
+		Type    string   `json:"type"`
+		Aliases []string `json:"aliases"`
+	}
+	if err = json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if len(exported) != 2 || exported[0].Name != "base" || exported[1].Name != "user" {
+		t.Fatalf("Expected entries sorted by name, got %s", data)
+	}
+	if exported[1].Type != "*testkit.TestUser" {
+		t.Errorf("Expected user type '*testkit.TestUser', got %q", exported[1].Type)
+	}
+	if !reflect.DeepEqual(exported[1].Aliases, []string{"account", "member"}) {
+		t.Errorf("Expected sorted aliases, got %v", exported[1].Aliases)
+	}
+	if exported[0].Type != "" || exported[0].Aliases == nil {
+		t.Errorf("Expected empty type and aliases for base builder, got %+v", exported[0])
+	}
+}
+
+func TestBuilderFactory_CreateUnique(t *testing.T) {
+	factory := NewBuilderFactory()
+	factory.Register("user", createUserBuilder)
+
+	first, err := factory.CreateUnique("user", "slot", "1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	userBuilder, ok := first.(*UserBuilder)
+	if !ok || userBuilder.GetTag("slot") != "1" {
+		t.Error("Expected builder to be tagged with the unique slot")
+	}
+
+	if _, err = factory.CreateUnique("user", "slot", "1"); err == nil {
+		t.Error("Expected error for a duplicate slot")
+	}
+	if _, err = factory.CreateUnique("user", "slot", "2"); err != nil {
+		t.Errorf("Expected a different slot to succeed, got %v", err)
+	}
+	if _, err = factory.CreateUnique("user", "lane", "1"); err != nil {
+		t.Errorf("Expected the same value under another key to succeed, got %v", err)
+	}
+
+	// Reset restores isolation
+	factory.Uniqueness().Reset()
+	if _, err = factory.CreateUnique("user", "slot", "1"); err != nil {
+		t.Errorf("Expected slot to be reusable after reset, got %v", err)
+	}
+
+	if _, err = factory.CreateUnique("nonexistent", "slot", "3"); err == nil {
+		t.Error("Expected error for non-existent builder")
+	}
+
+	// Builders that can't be tagged fail without claiming the value
+	factory.Register("keyed", func() Builder { return keyOnlyTagBuilder{} })
+	factory.Register("frozen", func() Builder {
+		builder := NewUserBuilder()
+		builder.Freeze()
+		return builder
+	})
+	for _, name := range []string{"keyed", "frozen"} {
+		if _, err = factory.CreateUnique(name, "slot", "9"); err == nil {
+			t.Errorf("Expected error tagging builder '%s'", name)
+		}
+	}
+	if _, err = factory.CreateUnique("user", "slot", "9"); err != nil {
+		t.Errorf("Expected a failed CreateUnique not to claim the value, got %v", err)
+	}
+}
+
+// keyOnlyTagBuilder has a WithTag method not taking a value.
+type keyOnlyTagBuilder struct{}
+
+func (keyOnlyTagBuilder) Build() any             { return nil }
+func (keyOnlyTagBuilder) Reset() Builder         { return keyOnlyTagBuilder{} }
+func (keyOnlyTagBuilder) Clone() Builder         { return keyOnlyTagBuilder{} }
+func (keyOnlyTagBuilder) WithTag(string) Builder { return keyOnlyTagBuilder{} }
+
+type nestedUserBuilder struct {
+	*contactUserBuilder
+}
+
+func TestBuilderConfig_ApplyTo_DeeplyEmbedded(t *testing.T) {
+	builder := &nestedUserBuilder{
+		contactUserBuilder: &contactUserBuilder{UserBuilder: NewUserBuilder()},
+	}
+	if builder.Base() != builder.BaseBuilder {
+		t.Fatal("Expected Base to return the embedded BaseBuilder")
+	}
+
+	config := NewBuilderConfig().
+		WithValidation(false).
+		WithTag("env", "test").
+		WithDefault("name", "Nested User")
+
+	if err := config.ApplyTo(builder); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if builder.IsValidationEnabled() {
+		t.Error("Expected validation to be disabled on the twice-embedded builder")
+	}
+	if builder.GetTag("env") != "test" {
+		t.Error("Expected tag to be applied to the twice-embedded builder")
+	}
+	if builder.user.Name != "Nested User" {
+		t.Error("Expected defaults to be applied through ConfigurableBuilder")
+	}
+}
+
+func TestBuilderFactory_RegisterMap(t *testing.T) {
+	factory := NewBuilderFactory()
+	createUser := func() Builder { return NewUserBuilder() }
+
+	err := factory.RegisterMap(map[string]func() Builder{
+		"user":  createUser,
+		"admin": createUser,
+		"":      createUser,
+		"ghost": nil,
+	})
+	if err == nil {
+		t.Fatal("Expected error for invalid entries")
+	}
+	if !strings.Contains(err.Error(), "'ghost'") {
+		t.Errorf("Expected error to name the invalid entry, got %v", err)
+	}
+
+	for _, name := range []string{"user", "admin"} {
+		if !factory.IsRegistered(name) {
+			t.Errorf("Expected valid entry '%s' to be registered", name)
+		}
+	}
+	if factory.IsRegistered("ghost") {
+		t.Error("Expected nil entry not to be registered")
+	}
+
+	if err = factory.RegisterMap(map[string]func() Builder{"guest": createUser}); err != nil {
+		t.Errorf("Expected no error for a valid map, got %v", err)
+	}
+}
+
+func TestBuilderFactory_Metrics(t *testing.T) {
+	factory := NewBuilderFactory()
+	_ = factory.Register("user", func() Builder {
+		time.Sleep(time.Millisecond)
+		return NewUserBuilder()
+	})
+	_ = factory.RegisterAlias("member", "user")
+
+	_, _ = factory.Create("user")
+	if len(factory.Metrics()) != 0 {
+		t.Error("Expected no metrics before EnableMetrics")
+	}
+
+	factory.EnableMetrics()
+	for range 2 {
+		_, _ = factory.Create("user")
+	}
+	_, _ = factory.Create("member")
+
+	metric, exists := factory.Metrics()["user"]
+	if !exists {
+		t.Fatal("Expected metrics for the user builder")
+	}
+	if metric.Count != 3 {
+		t.Errorf("Expected 3 creations, got %d", metric.Count)
+	}
+	if metric.TotalDuration <= 0 || metric.AverageDuration <= 0 {
+		t.Errorf("Expected non-zero durations, got %+v", metric)
+	}
+	if metric.AverageDuration != metric.TotalDuration/3 {
+		t.Errorf("Expected average to be total divided by count, got %+v", metric)
+	}
+
+	// Inspecting types is not a creation
+	_, _ = factory.TypeOf("user")
+	_, _ = factory.ExportJSON()
+	if count := factory.Metrics()["user"].Count; count != 3 {
+		t.Errorf("Expected TypeOf and ExportJSON not to be recorded, got %d creations", count)
+	}
+}
+
+func TestBuilderFactory_WithParent(t *testing.T) {
+	parent := NewBuilderFactory()
+	if err := parent.Register("user", func() Builder { return NewUserBuilder() }); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	parent.EnableMetrics()
+
+	child := NewBuilderFactory().WithParent(parent)
+	if err := child.Register("local", func() Builder { return NewBaseBuilder() }); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	builder, err := child.Create("user")
+	if err != nil {
+		t.Fatalf("Expected the child to resolve 'user' through its parent, got %v", err)
+	}
+	if _, ok := builder.(*UserBuilder); !ok {
+		t.Errorf("Expected a *UserBuilder, got %T", builder)
+	}
+	if !child.IsRegistered("user") || parent.IsRegistered("local") {
+		t.Error("Expected lookups to fall back to the parent only")
+	}
+	if parent.Metrics()["user"].Count != 1 {
+		t.Error("Expected the parent to record the creation")
+	}
+
+	// A parent cycle must not loop forever
+	parent.WithParent(child)
+	if _, err = child.Create("missing"); err == nil {
+		t.Error("Expected an error for a name missing from the whole chain")
+	}
+	if _, err = parent.Create("local"); err != nil {
+		t.Errorf("Expected the cyclic parent to still resolve names, got %v", err)
+	}
+}
+
+// failingConfigBuilder is a builder whose ApplyConfig fails after partially applying the configuration.
+type failingConfigBuilder struct {
+	*BaseBuilder
+}
+
+func (b *failingConfigBuilder) Build() any { return nil }
+
+func (b *failingConfigBuilder) Clone() Builder {
+	base, _ := b.BaseBuilder.Clone().(*BaseBuilder)
+	return &failingConfigBuilder{BaseBuilder: base}
+}
+
+func (b *failingConfigBuilder) ApplyConfig(*BuilderConfig) error {
+	b.WithTag("partial", "true")
+	return errors.New("apply failed")
+}
+
+func TestBuilderConfig_WithRollback(t *testing.T) {
+	newBuilder := func() *failingConfigBuilder {
+		builder := &failingConfigBuilder{BaseBuilder: NewBaseBuilder()}
+		builder.WithTag("team", "payments")
+		builder.RecordBuild()
+		return builder
+	}
+	config := NewBuilderConfig().WithValidation(false).WithTag("env", "ci")
+
+	partial := newBuilder()
+	if err := config.ApplyTo(partial); err == nil {
+		t.Fatal("Expected the ApplyConfig error")
+	}
+	if !partial.HasTag("env") || partial.IsValidationEnabled() {
+		t.Error("Expected a non-atomic ApplyTo to leave the builder partially configured")
+	}
+
+	atomic := newBuilder()
+	if err := config.WithRollback(true).ApplyTo(atomic); err == nil {
+		t.Fatal("Expected the ApplyConfig error")
+	}
+	if atomic.HasTag("env") || atomic.HasTag("partial") || atomic.GetTag("team") != "payments" {
+		t.Errorf("Expected the prior tags to be restored, got %v", atomic.GetTags())
+	}
+	if !atomic.IsValidationEnabled() {
+		t.Error("Expected the prior validation setting to be restored")
+	}
+	if atomic.BuildCount() != 1 {
+		t.Errorf("Expected the build counter to be kept, got %d", atomic.BuildCount())
+	}
+
+	if err := NewBuilderConfig().WithRollback(true).ApplyTo(NewUserBuilder()); err != nil {
+		t.Errorf("Expected a successful atomic ApplyTo, got %v", err)
+	}
+}
+
+// failingUserBuilder is a UserBuilder whose ApplyConfig always fails.
+type failingUserBuilder struct {
+	*UserBuilder
+}
+
+func (b *failingUserBuilder) Clone() Builder {
+	clone, _ := b.UserBuilder.Clone().(*UserBuilder)
+	return &failingUserBuilder{UserBuilder: clone}
+}
+
+func (b *failingUserBuilder) ApplyConfig(*BuilderConfig) error {
+	return errors.New("apply failed")
+}
+
+func TestBuilderConfig_WithRollbackSeeded(t *testing.T) {
+	newSeeded := func() *UserBuilder {
+		builder := NewUserBuilder()
+		builder.Seed(7)
+		return builder
+	}
+	config := NewBuilderConfig().WithRollback(true)
+
+	applied := newSeeded()
+	if err := config.ApplyTo(applied); err != nil {
+		t.Fatalf("Expected a successful atomic ApplyTo, got %v", err)
+	}
+	expected, _ := newSeeded().Clone().(*UserBuilder)
+	clone, _ := applied.Clone().(*UserBuilder)
+	if clone.CloneSeed() != expected.CloneSeed() {
+		t.Errorf("Expected clone seed %d after ApplyTo, got %d", expected.CloneSeed(), clone.CloneSeed())
+	}
+
+	failing := &failingUserBuilder{UserBuilder: newSeeded()}
+	if err := config.ApplyTo(failing); err == nil {
+		t.Fatal("Expected the ApplyConfig error")
+	}
+	if failing.CloneSeed() != 7 {
+		t.Errorf("Expected the seed to be restored, got %d", failing.CloneSeed())
+	}
+	clone, _ = failing.UserBuilder.Clone().(*UserBuilder)
+	if clone.CloneSeed() != expected.CloneSeed() {
+		t.Errorf("Expected clone seed %d after a rollback, got %d", expected.CloneSeed(), clone.CloneSeed())
+	}
+}
+
+func TestBuilderFactory_SnapshotRestore(t *testing.T) {
+	factory := NewBuilderFactory()
+	_ = factory.Register("user", func() Builder { return NewUserBuilder() })
+	_ = factory.Uniqueness().Claim("email", "a@example.com")
+	snapshot := factory.Snapshot()
+
+	_ = factory.Register("admin", func() Builder { return NewUserBuilder() })
+	_ = factory.RegisterAlias("member", "user")
+	_ = factory.Uniqueness().Claim("email", "b@example.com")
+	factory.Restore(snapshot)
+
+	if !factory.IsRegistered("user") {
+		t.Error("Expected registrations from before the snapshot to be kept")
+	}
+	if factory.IsRegistered("admin") || factory.IsRegistered("member") {
+		t.Error("Expected registrations made after the snapshot to be discarded")
+	}
+	if factory.Uniqueness().Claim("email", "a@example.com") == nil {
+		t.Error("Expected claims from before the snapshot to be kept")
+	}
+	if err := factory.Uniqueness().Claim("email", "b@example.com"); err != nil {
+		t.Errorf("Expected claims made after the snapshot to be discarded, got %v", err)
+	}
+
+	var zero BuilderFactory
+	zero.Restore(zero.Snapshot())
+	if err := zero.Uniqueness().Claim("email", "a@example.com"); err != nil {
+		t.Errorf("Expected a zero-value factory to snapshot and restore, got %v", err)
+	}
+}
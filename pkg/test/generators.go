@@ -0,0 +1,229 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// RoundRobin cycles through a fixed slice of values.
+// It is safe for concurrent use, so a single instance can be shared across builders.
+type RoundRobin[T any] struct {
+	mu    sync.Mutex
+	items []T
+	next  int
+}
+
+// NewRoundRobin creates a new RoundRobin over a copy of the given items.
+func NewRoundRobin[T any](items ...T) *RoundRobin[T] {
+	return &RoundRobin[T]{
+		items: append([]T(nil), items...),
+	}
+}
+
+// Next returns the next value, wrapping around at the end of the slice.
+// Returns the zero value if the RoundRobin has no items.
+func (r *RoundRobin[T]) Next() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var zero T
+	if len(r.items) == 0 {
+		return zero
+	}
+	item := r.items[r.next]
+	r.next = (r.next + 1) % len(r.items)
+	return item
+}
+
+// Peek returns the value the next call to Next will return, without advancing.
+func (r *RoundRobin[T]) Peek() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var zero T
+	if len(r.items) == 0 {
+		return zero
+	}
+	return r.items[r.next]
+}
+
+// WeightedOption is a value picked by a WeightedChoice with a probability proportional to its weight.
+type WeightedOption[T any] struct {
+	Value  T
+	Weight float64
+}
+
+// WeightedChoice picks values following a weighted distribution, e.g. 80% active users.
+type WeightedChoice[T any] struct {
+	options []WeightedOption[T]
+	total   float64
+}
+
+// NewWeightedChoice creates a new WeightedChoice over the options.
+// Weights must be non-negative and not all zero.
+func NewWeightedChoice[T any](options ...WeightedOption[T]) (*WeightedChoice[T], error) {
+	var total float64
+	for i, option := range options {
+		if option.Weight < 0 {
+			return nil, fmt.Errorf("weight of option %d must be non-negative, got %g", i, option.Weight)
+		}
+		total += option.Weight
+	}
+	if total == 0 {
+		return nil, errors.New("at least one weight must be positive")
+	}
+	return &WeightedChoice[T]{
+		options: append([]WeightedOption[T](nil), options...),
+		total:   total,
+	}, nil
+}
+
+// Pick returns a value drawn from rng following the weights. A nil rng uses an unseeded source.
+func (c *WeightedChoice[T]) Pick(rng *rand.Rand) T {
+	var target float64
+	if rng == nil {
+		target = rand.Float64() * c.total //nolint:gosec // test data, not security sensitive
+	} else {
+		target = rng.Float64() * c.total
+	}
+
+	var last T
+	for _, option := range c.options {
+		if option.Weight == 0 {
+			continue
+		}
+		if target < option.Weight {
+			return option.Value
+		}
+		target -= option.Weight
+		last = option.Value
+	}
+	// Floating point rounding can leave a tiny remainder: fall back to the last weighted option
+	return last
+}
+
+// Combinations returns the cartesian product of the dimensions, in order, with the last dimension varying fastest.
+// Its length is the product of the dimension sizes; no dimensions, or an empty one, yield no combinations.
+func Combinations[T any](dimensions ...[]T) [][]T {
+	if len(dimensions) == 0 {
+		return nil
+	}
+	total := 1
+	for _, dimension := range dimensions {
+		total *= len(dimension)
+	}
+
+	result := make([][]T, 0, total)
+	for i := range total {
+		combination := make([]T, len(dimensions))
+		rest := i
+		for d := len(dimensions) - 1; d >= 0; d-- {
+			size := len(dimensions[d])
+			combination[d] = dimensions[d][rest%size]
+			rest /= size
+		}
+		result = append(result, combination)
+	}
+	return result
+}
+
+// ErrSequenceExhausted is returned when a jittered Sequence has issued all of its values.
+var ErrSequenceExhausted = errors.New("sequence exhausted")
+
+// Sequence generates arithmetic progressions of integers, such as IDs.
+// It is safe for concurrent use; each Next call returns a distinct value.
+type Sequence struct {
+	start  int
+	step   int
+	issued atomic.Int64
+	// exhausted records that Next ran past the end of a jittered sequence, reported by Err
+	exhausted atomic.Bool
+	// values holds the pre-generated values of a jittered sequence, nil for arithmetic sequences
+	values []int
+}
+
+// NewSequence creates a new Sequence returning start, start+step, start+2*step, and so on.
+// Negative steps count down.
+func NewSequence(start, step int) *Sequence {
+	return &Sequence{
+		start: start,
+		step:  step,
+	}
+}
+
+// NewJitteredSequence creates a Sequence returning the count values start, start+1, ..., start+count-1
+// in a shuffled order, so IDs are unique but tests can't rely on them increasing.
+// The order is reproducible for a given rng; a nil rng uses an unseeded source.
+// Once all values are issued, TryNext returns ErrSequenceExhausted. Draw from a jittered sequence with TryNext,
+// or with UserBuilder.WithIDFrom which records the error on the builder; after Next, check Err.
+func NewJitteredSequence(start, count int, rng *rand.Rand) *Sequence {
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())) //nolint:gosec // test data, not security sensitive
+	}
+	values := make([]int, max(count, 0))
+	for i := range values {
+		values[i] = start + i
+	}
+	rng.Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+	return &Sequence{start: start, step: 1, values: values}
+}
+
+// Next returns the next value and advances the sequence. It never fails for arithmetic sequences.
+// Once a jittered sequence is exhausted, Next returns 0 and Err reports ErrSequenceExhausted;
+// use TryNext or UserBuilder.WithIDFrom to handle exhaustion at each draw instead.
+func (s *Sequence) Next() int {
+	value, err := s.TryNext()
+	if err != nil {
+		s.exhausted.Store(true)
+	}
+	return value
+}
+
+// Err returns ErrSequenceExhausted if Next was called on an exhausted jittered sequence since the last Reset,
+// and nil otherwise.
+func (s *Sequence) Err() error {
+	if s.exhausted.Load() {
+		return ErrSequenceExhausted
+	}
+	return nil
+}
+
+// TryNext returns the next value and advances the sequence,
+// or ErrSequenceExhausted if a jittered sequence has no values left.
+func (s *Sequence) TryNext() (int, error) {
+	value, ok := s.valueAt(s.issued.Add(1) - 1)
+	if !ok {
+		return 0, ErrSequenceExhausted
+	}
+	return value, nil
+}
+
+// Peek returns the value the next call to Next will return, without advancing.
+// It returns 0 if a jittered sequence has no values left.
+func (s *Sequence) Peek() int {
+	value, _ := s.valueAt(s.issued.Load())
+	return value
+}
+
+// Reset restarts the sequence from its start value and clears Err.
+// A jittered sequence replays the same shuffled order.
+func (s *Sequence) Reset() {
+	s.issued.Store(0)
+	s.exhausted.Store(false)
+}
+
+// valueAt returns the value at a given position of the sequence, and false if the position is out of range.
+func (s *Sequence) valueAt(position int64) (int, bool) {
+	if s.values != nil {
+		if position >= int64(len(s.values)) {
+			return 0, false
+		}
+		return s.values[position], true
+	}
+	return s.start + int(position)*s.step, true
+}
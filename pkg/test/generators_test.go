@@ -0,0 +1,244 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestRoundRobin_Next(t *testing.T) {
+	rr := NewRoundRobin("a", "b", "c")
+
+	expected := []string{"a", "b", "c", "a", "b"}
+	for i, want := range expected {
+		if got := rr.Next(); got != want {
+			t.Errorf("Call %d: expected %q, got %q", i, want, got)
+		}
+	}
+
+	empty := NewRoundRobin[int]()
+	if empty.Next() != 0 {
+		t.Error("Expected zero value from empty RoundRobin")
+	}
+}
+
+func TestRoundRobin_Concurrent(t *testing.T) {
+	rr := NewRoundRobin(1, 2, 3)
+	counts := make(map[int]int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range 30 {
+		wg.Go(func() {
+			value := rr.Next()
+			mu.Lock()
+			counts[value]++
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	for _, value := range []int{1, 2, 3} {
+		if counts[value] != 10 {
+			t.Errorf("Expected value %d to be returned 10 times, got %d", value, counts[value])
+		}
+	}
+}
+
+func TestUserBuilder_WithEmailFrom(t *testing.T) {
+	pool := NewRoundRobin("a@example.com", "b@example.com", "c@example.com")
+	builder := NewUserBuilder().WithName("John Doe").WithEmailFrom(pool)
+
+	expected := []string{
+		"a@example.com", "b@example.com", "c@example.com",
+		"a@example.com", "b@example.com", "c@example.com",
+		"a@example.com", "b@example.com", "c@example.com",
+		"a@example.com",
+	}
+	for i, want := range expected {
+		user, ok := builder.Build().(*TestUser)
+		if !ok {
+			t.Fatalf("Build %d: expected *TestUser", i)
+		}
+		if user.Email != want {
+			t.Errorf("Build %d: expected email %q, got %q", i, want, user.Email)
+		}
+	}
+}
+
+func TestSequence_Next(t *testing.T) {
+	seq := NewSequence(1000, 10)
+
+	if seq.Peek() != 1000 {
+		t.Errorf("Expected Peek to return 1000, got %d", seq.Peek())
+	}
+	for _, want := range []int{1000, 1010, 1020} {
+		if got := seq.Next(); got != want {
+			t.Errorf("Expected %d, got %d", want, got)
+		}
+	}
+	if seq.Peek() != 1030 {
+		t.Errorf("Expected Peek to return 1030, got %d", seq.Peek())
+	}
+	if seq.Peek() != 1030 {
+		t.Error("Peek should not advance the sequence")
+	}
+
+	seq.Reset()
+	if seq.Next() != 1000 {
+		t.Error("Expected Reset to restart the sequence")
+	}
+}
+
+func TestSequence_NegativeStep(t *testing.T) {
+	seq := NewSequence(3, -1)
+
+	for _, want := range []int{3, 2, 1, 0, -1} {
+		if got := seq.Next(); got != want {
+			t.Errorf("Expected %d, got %d", want, got)
+		}
+	}
+}
+
+func TestSequence_Concurrent(t *testing.T) {
+	const goroutines = 8
+	const perGoroutine = 250
+
+	seq := NewSequence(1, 1)
+	seen := make(map[int]bool)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range goroutines {
+		wg.Go(func() {
+			for range perGoroutine {
+				value := seq.Next()
+				mu.Lock()
+				if seen[value] {
+					t.Errorf("Duplicate value %d", value)
+				}
+				seen[value] = true
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*perGoroutine {
+		t.Errorf("Expected %d distinct values, got %d", goroutines*perGoroutine, len(seen))
+	}
+	if seq.Peek() != goroutines*perGoroutine+1 {
+		t.Errorf("Expected next value %d, got %d", goroutines*perGoroutine+1, seq.Peek())
+	}
+}
+
+func TestRoundRobin_Peek(t *testing.T) {
+	rr := NewRoundRobin("a", "b")
+	if rr.Peek() != "a" || rr.Peek() != "a" {
+		t.Error("Expected Peek not to advance")
+	}
+	rr.Next()
+	if rr.Peek() != "b" {
+		t.Errorf("Expected Peek to return the next value, got %q", rr.Peek())
+	}
+	if NewRoundRobin[string]().Peek() != "" {
+		t.Error("Expected zero value for an empty RoundRobin")
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	combinations := Combinations([]string{"a", "b"}, []string{"x", "y", "z"})
+	if len(combinations) != 6 {
+		t.Fatalf("Expected 6 combinations, got %d", len(combinations))
+	}
+	if combinations[0][0] != "a" || combinations[0][1] != "x" || combinations[5][0] != "b" || combinations[5][1] != "z" {
+		t.Errorf("Expected ordered combinations, got %v", combinations)
+	}
+
+	if got := Combinations([]int{1, 2}, []int{}); len(got) != 0 {
+		t.Errorf("Expected no combinations with an empty dimension, got %v", got)
+	}
+	if got := Combinations[int](); len(got) != 0 {
+		t.Errorf("Expected no combinations without dimensions, got %v", got)
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	choice, err := NewWeightedChoice(
+		WeightedOption[string]{Value: "gold", Weight: 1},
+		WeightedOption[string]{Value: "silver", Weight: 3},
+		WeightedOption[string]{Value: "never", Weight: 0},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	const samples = 10_000
+	rng := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // deterministic test data
+	counts := make(map[string]int)
+	for range samples {
+		counts[choice.Pick(rng)]++
+	}
+
+	if ratio := float64(counts["gold"]) / samples; math.Abs(ratio-0.25) > 0.02 {
+		t.Errorf("Expected about 25%% gold, got %.3f", ratio)
+	}
+	if counts["never"] != 0 {
+		t.Errorf("Expected zero-weight options never to be picked, got %d", counts["never"])
+	}
+
+	if _, err = NewWeightedChoice(WeightedOption[int]{Value: 1, Weight: -1}); err == nil {
+		t.Error("Expected error for a negative weight")
+	}
+	if _, err = NewWeightedChoice(WeightedOption[int]{Value: 1, Weight: 0}); err == nil {
+		t.Error("Expected error when all weights are zero")
+	}
+}
+
+func TestNewJitteredSequence(t *testing.T) {
+	newRng := func() *rand.Rand { return rand.New(rand.NewPCG(5, 5)) } //nolint:gosec // deterministic test data
+
+	seq := NewJitteredSequence(100, 20, newRng())
+	seen := make(map[int]bool)
+	var values []int
+	for range 20 {
+		value := seq.Next()
+		if value < 100 || value >= 120 || seen[value] {
+			t.Fatalf("Expected unique values in [100, 120), got %d", value)
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	if slices.IsSorted(values) {
+		t.Errorf("Expected a shuffled order, got %v", values)
+	}
+
+	if _, err := seq.TryNext(); !errors.Is(err, ErrSequenceExhausted) {
+		t.Errorf("Expected ErrSequenceExhausted, got %v", err)
+	}
+	if seq.Err() != nil {
+		t.Errorf("Expected TryNext not to set Err, got %v", seq.Err())
+	}
+	if value := seq.Next(); value != 0 || !errors.Is(seq.Err(), ErrSequenceExhausted) {
+		t.Errorf("Expected Next to return 0 and report ErrSequenceExhausted via Err, got %d (%v)", value, seq.Err())
+	}
+	seq.Reset()
+	if seq.Err() != nil {
+		t.Errorf("Expected Reset to clear Err, got %v", seq.Err())
+	}
+
+	replay := NewJitteredSequence(100, 20, newRng())
+	for i := range values {
+		if value, err := replay.TryNext(); err != nil || value != values[i] {
+			t.Fatalf("Expected the same seed to reproduce the order, got %d (%v) at %d", value, err, i)
+		}
+	}
+
+	replay.Reset()
+	if replay.Peek() != values[0] {
+		t.Error("Expected Reset to replay the shuffled order")
+	}
+}
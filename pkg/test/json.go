@@ -0,0 +1,173 @@
+package testkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// stableJSONOptions holds the settings applied by StableJSON.
+type stableJSONOptions struct {
+	sortSlices bool
+}
+
+// StableJSONOption configures StableJSON.
+type StableJSONOption func(*stableJSONOptions)
+
+// WithSortSlices makes StableJSON sort []string and []int metadata values before marshalling.
+// Only use it when element order carries no meaning: sorting a semantically ordered slice,
+// such as a priority list, changes what the output represents.
+func WithSortSlices() StableJSONOption {
+	return func(o *stableJSONOptions) {
+		o.sortSlices = true
+	}
+}
+
+// StableJSON marshals v to JSON with deterministic output. Map keys are always sorted;
+// with WithSortSlices, []string and []int values in the metadata of a TestUser,
+// or in a map[string]any, are sorted too. The input is never modified.
+func StableJSON(v any, options ...StableJSONOption) ([]byte, error) {
+	var opts stableJSONOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if opts.sortSlices {
+		switch value := v.(type) {
+		case *TestUser:
+			if value != nil {
+				user := copyUser(value)
+				user.Metadata = sortedSliceValues(value.Metadata)
+				v = user
+			}
+		case TestUser:
+			user := copyUser(&value)
+			user.Metadata = sortedSliceValues(value.Metadata)
+			v = user
+		case map[string]any:
+			v = sortedSliceValues(value)
+		}
+	}
+	return json.Marshal(v)
+}
+
+// sortedSliceValues returns a copy of values with its []string and []int entries sorted.
+func sortedSliceValues(values map[string]any) map[string]any {
+	result := maps.Clone(values)
+	for key, value := range result {
+		switch slice := value.(type) {
+		case []string:
+			result[key] = slices.Sorted(slices.Values(slice))
+		case []int:
+			result[key] = slices.Sorted(slices.Values(slice))
+		}
+	}
+	return result
+}
+
+// userBuilderState is the serialized configuration of a UserBuilder, written by StateJSON and read by UserBuilderFromJSON.
+type userBuilderState struct {
+	Fields            map[string]any    `json:"fields"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	Metadata          map[string]any    `json:"metadata,omitempty"`
+	ValidationEnabled *bool             `json:"validation_enabled,omitempty"`
+}
+
+// StateJSON serializes the explicitly set fields, tags, metadata and validation flag of the builder,
+// in the format read by UserBuilderFromJSON. Randomized fields, hooks and validators are not included.
+func (b *UserBuilder) StateJSON() ([]byte, error) {
+	enabled := b.IsValidationEnabled()
+	state := userBuilderState{
+		Fields:            make(map[string]any, len(b.setFields)),
+		Tags:              b.GetTags(),
+		Metadata:          b.user.Metadata,
+		ValidationEnabled: &enabled,
+	}
+	for field := range b.setFields {
+		switch field {
+		case "id":
+			state.Fields[field] = b.user.ID
+		case "name":
+			state.Fields[field] = b.user.Name
+		case "email":
+			state.Fields[field] = b.user.Email
+		case "age":
+			state.Fields[field] = b.user.Age
+		case "active":
+			state.Fields[field] = b.user.Active
+		}
+	}
+	return json.Marshal(state)
+}
+
+// UserBuilderFromJSON creates an unbuilt UserBuilder configured from a JSON fixture:
+//
+//	{
+//	  "fields": {"id": 1, "name": "Jane Doe", "email": "jane@example.com", "age": 30, "active": true},
+//	  "tags": {"role": "admin"},
+//	  "metadata": {"plan": "pro"},
+//	  "validation_enabled": true
+//	}
+//
+// All sections are optional. Whole-number metadata values are decoded as int, like the id and age fields.
+// Unknown fields and fields of the wrong type are reported as errors.
+func UserBuilderFromJSON(raw []byte) (*UserBuilder, error) {
+	var state userBuilderState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("cannot decode user builder: %w", err)
+	}
+
+	builder := NewUserBuilder()
+	if state.ValidationEnabled != nil {
+		builder.WithValidation(*state.ValidationEnabled)
+	}
+	for _, field := range slices.Sorted(maps.Keys(state.Fields)) {
+		if err := builder.setFieldFromJSON(field, state.Fields[field]); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(state.Tags)) {
+		builder.WithTag(key, state.Tags[key])
+	}
+	for _, key := range slices.Sorted(maps.Keys(state.Metadata)) {
+		builder.WithMetadata(key, normalizeJSONNumber(state.Metadata[key]))
+	}
+	return builder, nil
+}
+
+// setFieldFromJSON sets a user field from its JSON-decoded value, converting whole numbers to int.
+func (b *UserBuilder) setFieldFromJSON(field string, value any) error {
+	var ok bool
+	switch field {
+	case "id", "age":
+		var number int
+		if number, ok = normalizeJSONNumber(value).(int); ok {
+			if field == "id" {
+				b.WithID(number)
+			} else {
+				b.WithAge(number)
+			}
+		}
+	case "name", "email":
+		var text string
+		if text, ok = value.(string); ok {
+			if field == "name" {
+				b.WithName(text)
+			} else {
+				b.WithEmail(text)
+			}
+		}
+	case "active":
+		var active bool
+		if active, ok = value.(bool); ok {
+			b.WithActive(active)
+		}
+	default:
+		return fmt.Errorf("cannot decode user builder: unknown field '%s'", field)
+	}
+	if !ok {
+		return fmt.Errorf("cannot decode user builder: field '%s' has invalid value %v", field, value)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStableJSON(t *testing.T) {
+	user := &TestUser{
+		ID:   1,
+		Name: "Jane",
+		Tags: map[string]string{},
+		Metadata: map[string]any{
+			"roles":  []string{"writer", "admin", "reader"},
+			"scores": []int{30, 10, 20},
+			"team":   "core",
+		},
+	}
+
+	unsorted, err := StableJSON(user)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"ID":1,"Name":"Jane","Email":"","Age":0,"Active":false,"Tags":{},` +
+		`"Metadata":{"roles":["writer","admin","reader"],"scores":[30,10,20],"team":"core"},"SchemaVersion":0}`
+	if string(unsorted) != expected {
+		t.Errorf("Expected slices to keep their order by default, got %s", unsorted)
+	}
+
+	sorted, err := StableJSON(user, WithSortSlices())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected = `{"ID":1,"Name":"Jane","Email":"","Age":0,"Active":false,"Tags":{},` +
+		`"Metadata":{"roles":["admin","reader","writer"],"scores":[10,20,30],"team":"core"},"SchemaVersion":0}`
+	if string(sorted) != expected {
+		t.Errorf("Expected sorted slices, got %s", sorted)
+	}
+
+	if roles, _ := user.Metadata["roles"].([]string); !slices.Equal(roles, []string{"writer", "admin", "reader"}) {
+		t.Errorf("Expected the input not to be modified, got %v", roles)
+	}
+
+	fromMap, err := StableJSON(map[string]any{"ids": []int{3, 1, 2}}, WithSortSlices())
+	if err != nil || string(fromMap) != `{"ids":[1,2,3]}` {
+		t.Errorf("Expected sorted map slices, got %s (%v)", fromMap, err)
+	}
+}
+
+func TestUserBuilderFromJSON(t *testing.T) {
+	original := NewUserBuilder().
+		WithID(42).
+		WithName("Jane Doe").
+		WithEmail("jane@example.com").
+		WithAge(30).
+		WithActive(true).
+		WithMetadata("plan", "pro").
+		WithMetadata("seats", 5)
+	original.WithTag("role", "admin")
+
+	raw, err := original.StateJSON()
+	if err != nil {
+		t.Fatalf("Expected no error serializing, got %v", err)
+	}
+	builder, err := UserBuilderFromJSON(raw)
+	if err != nil {
+		t.Fatalf("Expected no error reconstructing, got %v", err)
+	}
+	if builder.GetTag("role") != "admin" || !builder.IsValidationEnabled() {
+		t.Errorf("Expected tags and validation flag to be restored, got %v", builder.tags)
+	}
+
+	expected, _ := original.Build().(*TestUser)
+	user, ok := builder.WithName("Jane Smith").Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected reconstructed builder to build")
+	}
+	if !UsersEqualIgnoring(expected, user, "name") || user.Name != "Jane Smith" {
+		t.Errorf("Expected round-tripped user %+v, got %+v", expected, user)
+	}
+	if user.ID != 42 || user.Age != 30 || user.Metadata["seats"] != 5 {
+		t.Errorf("Expected whole numbers decoded as int, got %+v", user)
+	}
+
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	original.WithTagTTL("token", "abc", time.Minute, clock)
+	clock.Advance(time.Minute)
+	if raw, err = original.StateJSON(); err != nil {
+		t.Fatalf("Expected no error serializing, got %v", err)
+	}
+	if builder, err = UserBuilderFromJSON(raw); err != nil || builder.HasTag("token") || builder.GetTag("role") != "admin" {
+		t.Errorf("Expected expired tags not to be serialized, got %v (%v)", builder, err)
+	}
+
+	large := NewUserBuilder().WithID(3_000_000_000).WithName("Jane Doe").WithEmail("jane@example.com")
+	if raw, err = large.StateJSON(); err != nil {
+		t.Fatalf("Expected no error serializing, got %v", err)
+	}
+	if builder, err = UserBuilderFromJSON(raw); err != nil {
+		t.Fatalf("Expected a large ID to round-trip, got %v", err)
+	}
+	if user, _ = builder.Build().(*TestUser); user == nil || user.ID != 3_000_000_000 {
+		t.Errorf("Expected ID 3000000000, got %v", user)
+	}
+
+	for _, raw := range []string{
+		`{"fields": {"age": 30.5}}`,
+		`{"fields": {"name": 7}}`,
+		`{"fields": {"nickname": "jd"}}`,
+		`not json`,
+	} {
+		if _, err = UserBuilderFromJSON([]byte(raw)); err == nil {
+			t.Errorf("Expected error decoding %s", raw)
+		}
+	}
+}
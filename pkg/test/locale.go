@@ -0,0 +1,54 @@
+package testkit
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// DefaultLocale is the locale WithLocale falls back to for unknown locales.
+const DefaultLocale = "en_US"
+
+// localeNames holds the curated name pools of a locale.
+type localeNames struct {
+	given  []string
+	family []string
+	// familyFirst writes the family name before the given name
+	familyFirst bool
+}
+
+// name returns a random full name drawn from rng, in the locale's order.
+func (l localeNames) name(rng *rand.Rand) string {
+	given := l.given[rng.IntN(len(l.given))]
+	family := l.family[rng.IntN(len(l.family))]
+	if l.familyFirst {
+		return family + " " + given
+	}
+	return given + " " + family
+}
+
+//nolint:gochecknoglobals // read-only embedded fixture data
+var locales = map[string]localeNames{
+	"en_US": {
+		given:  []string{"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda"},
+		family: []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis"},
+	},
+	"de_DE": {
+		given:  []string{"Lukas", "Anna", "Jürgen", "Sophie", "Maximilian", "Lena", "Björn", "Käthe"},
+		family: []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Schäfer"},
+	},
+	"ja_JP": {
+		given:       []string{"太郎", "花子", "翔太", "陽菜", "大輝", "結衣", "蓮", "さくら"},
+		family:      []string{"佐藤", "鈴木", "高橋", "田中", "伊藤", "渡辺", "山本", "中村"},
+		familyFirst: true,
+	},
+	"pt_BR": {
+		given:  []string{"João", "Maria", "José", "Ana", "Luís", "Júlia", "Mateus", "Beatriz"},
+		family: []string{"Silva", "Santos", "Oliveira", "Souza", "Pereira", "Conceição", "Araújo", "Gonçalves"},
+	},
+}
+
+// SupportedLocales returns the locales accepted by WithLocale, sorted.
+func SupportedLocales() []string {
+	return slices.Sorted(maps.Keys(locales))
+}
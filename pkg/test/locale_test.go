@@ -0,0 +1,46 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUserBuilder_WithLocale(t *testing.T) {
+	newRng := func() *rand.Rand { return rand.New(rand.NewPCG(21, 21)) } //nolint:gosec // deterministic test data
+
+	for _, locale := range []string{"de_DE", "ja_JP"} {
+		name := NewUserBuilder().WithLocale(locale, newRng()).user.Name
+		first, second, found := strings.Cut(name, " ")
+		if !found {
+			t.Fatalf("%s: expected a two-part name, got %q", locale, name)
+		}
+		// ja_JP writes the family name first
+		given, family := first, second
+		if locale == "ja_JP" {
+			given, family = second, first
+		}
+		if !slices.Contains(locales[locale].given, given) || !slices.Contains(locales[locale].family, family) {
+			t.Errorf("%s: expected a name from the locale pools, got %q", locale, name)
+		}
+		if again := NewUserBuilder().WithLocale(locale, newRng()).user.Name; again != name {
+			t.Errorf("%s: expected the same seed to yield the same name, got %q and %q", locale, name, again)
+		}
+	}
+
+	builder := NewUserBuilder().WithLocale("xx_XX", newRng())
+	if !builder.HasWarnings() {
+		t.Error("Expected a warning for an unknown locale")
+	}
+	if given, _, _ := strings.Cut(builder.user.Name, " "); !slices.Contains(locales[DefaultLocale].given, given) {
+		t.Errorf("Expected a fallback to %s, got %q", DefaultLocale, builder.user.Name)
+	}
+}
+
+func TestSupportedLocales(t *testing.T) {
+	supported := SupportedLocales()
+	if !slices.IsSorted(supported) || !slices.Contains(supported, DefaultLocale) {
+		t.Errorf("Expected sorted locales including the default, got %v", supported)
+	}
+}
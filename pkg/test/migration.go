@@ -0,0 +1,89 @@
+package testkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// CurrentSchemaVersion is the current version of the TestUser shape.
+// Version 1 predates the Active field.
+const CurrentSchemaVersion = 2
+
+// schemaVersionKey is the JSON key holding the schema version of a serialized TestUser.
+const schemaVersionKey = "SchemaVersion"
+
+//nolint:gochecknoglobals // registry shared by all fixtures, like DefaultFactory
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[int]func(map[string]any){
+		1: migrateUserV1,
+	}
+)
+
+// RegisterMigration registers a function upgrading a serialized TestUser from version from to from+1.
+// The function edits the decoded JSON object in place; the version is bumped by MigrateUser.
+// Registering an existing version replaces its migration.
+func RegisterMigration(from int, fn func(map[string]any)) error {
+	if from < 1 {
+		return fmt.Errorf("invalid migration source version %d", from)
+	}
+	if fn == nil {
+		return errors.New("migration function cannot be nil")
+	}
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migrations[from] = fn
+	return nil
+}
+
+// MigrateUser decodes a serialized TestUser, upgrading it to CurrentSchemaVersion with the registered migrations.
+// JSON without a SchemaVersion is treated as version 1.
+func MigrateUser(raw []byte) (*TestUser, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("cannot decode user: %w", err)
+	}
+
+	version := 1
+	if value, exists := fields[schemaVersionKey]; exists {
+		number, ok := value.(float64)
+		if !ok || number != float64(int(number)) {
+			return nil, fmt.Errorf("invalid schema version %v", value)
+		}
+		version = int(number)
+	}
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("schema version %d is newer than the current version %d", version, CurrentSchemaVersion)
+	}
+
+	migrationsMu.RLock()
+	for ; version < CurrentSchemaVersion; version++ {
+		migrate, exists := migrations[version]
+		if !exists {
+			migrationsMu.RUnlock()
+			return nil, fmt.Errorf("no migration registered from schema version %d", version)
+		}
+		migrate(fields)
+	}
+	migrationsMu.RUnlock()
+	fields[schemaVersionKey] = CurrentSchemaVersion
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode migrated user: %w", err)
+	}
+	var user TestUser
+	if err = json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("cannot decode migrated user: %w", err)
+	}
+	return &user, nil
+}
+
+// migrateUserV1 adds the Active field introduced in version 2, defaulting existing users to active.
+func migrateUserV1(fields map[string]any) {
+	if _, exists := fields["Active"]; !exists {
+		fields["Active"] = true
+	}
+}
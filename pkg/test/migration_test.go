@@ -0,0 +1,79 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"testing"
+)
+
+func TestMigrateUser(t *testing.T) {
+	user, err := MigrateUser([]byte(`{"ID":1,"Name":"Legacy","Email":"legacy@example.com","Age":40}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", CurrentSchemaVersion, user.SchemaVersion)
+	}
+	if !user.Active {
+		t.Error("Expected the v1 migration to default Active to true")
+	}
+	if user.Name != "Legacy" || user.Age != 40 {
+		t.Errorf("Expected existing fields to be kept, got %+v", user)
+	}
+
+	current, err := MigrateUser([]byte(`{"Name":"Current","Active":false,"SchemaVersion":2}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if current.Active {
+		t.Error("Expected current-version users not to be migrated")
+	}
+}
+
+func TestMigrateUser_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "invalid JSON", raw: `{`},
+		{name: "invalid version", raw: `{"SchemaVersion":"two"}`},
+		{name: "future version", raw: `{"SchemaVersion":99}`},
+		{name: "no migration path", raw: `{"SchemaVersion":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MigrateUser([]byte(tt.raw)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRegisterMigration(t *testing.T) {
+	if err := RegisterMigration(0, func(map[string]any) {}); err == nil {
+		t.Error("Expected error for an invalid source version")
+	}
+	if err := RegisterMigration(1, nil); err == nil {
+		t.Error("Expected error for a nil migration")
+	}
+
+	migrationsMu.RLock()
+	original := migrations[1]
+	migrationsMu.RUnlock()
+	t.Cleanup(func() { _ = RegisterMigration(1, original) })
+
+	err := RegisterMigration(1, func(fields map[string]any) {
+		fields["Active"] = false
+		fields["Name"] = "Migrated"
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	user, err := MigrateUser([]byte(`{"Name":"Legacy"}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Migrated" || user.Active {
+		t.Errorf("Expected the registered migration to be applied, got %+v", user)
+	}
+}
@@ -0,0 +1,98 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ParallelBuildUsers builds the given builders concurrently across a pool of workers.
+// The output slice preserves the input order; failed builds leave a nil entry and
+// all failures are joined into the returned error.
+//
+// Builders are not thread-safe, so each builder in the slice must be a distinct instance
+// that is not used elsewhere while the build runs.
+func ParallelBuildUsers(builders []*UserBuilder, workers int) ([]*TestUser, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("workers must be positive, got %d", workers)
+	}
+
+	users := make([]*TestUser, len(builders))
+	errs := make([]error, len(builders))
+	runIndexed(len(builders), workers, func(i int) {
+		users[i], errs[i] = buildUserAt(builders, i)
+	})
+	return users, errors.Join(errs...)
+}
+
+// runIndexed calls fn for every index in [0, n) across up to workers goroutines, and waits for all calls.
+// Indexes are handed out in increasing order; fn must be safe to call concurrently for distinct indexes.
+func runIndexed(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Go(func() {
+			for i := range indexes {
+				fn(i)
+			}
+		})
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// buildUserAt builds the user at the given index, wrapping any failure with the index.
+func buildUserAt(builders []*UserBuilder, i int) (*TestUser, error) {
+	if builders[i] == nil {
+		return nil, fmt.Errorf("builder %d: builder cannot be nil", i)
+	}
+	switch result := builders[i].Build().(type) {
+	case *TestUser:
+		return result, nil
+	case error:
+		return nil, fmt.Errorf("builder %d: %w", i, result)
+	default:
+		return nil, fmt.Errorf("builder %d: unexpected build result %T", i, result)
+	}
+}
+
+// SerialGate serializes the builds of the builders guarded by it, in the order the builds are called,
+// even across goroutines. This makes shared resources such as a Sequence assign values in a stable order,
+// at the cost of parallelism. The zero value is ready to use.
+//
+// Builds nested in a gated build, e.g. through user references, must not use the same gate.
+type SerialGate struct {
+	mu      sync.Mutex
+	turn    *sync.Cond
+	next    uint64
+	serving uint64
+}
+
+// NewSerialGate creates a new SerialGate.
+func NewSerialGate() *SerialGate {
+	return &SerialGate{}
+}
+
+// enter waits for the caller's turn, in call order, and returns the function ending it.
+func (g *SerialGate) enter() func() {
+	g.mu.Lock()
+	if g.turn == nil {
+		g.turn = sync.NewCond(&g.mu)
+	}
+	ticket := g.next
+	g.next++
+	for g.serving != ticket {
+		g.turn.Wait()
+	}
+	g.mu.Unlock()
+
+	return func() {
+		g.mu.Lock()
+		g.serving++
+		g.turn.Broadcast()
+		g.mu.Unlock()
+	}
+}
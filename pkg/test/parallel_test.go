@@ -0,0 +1,124 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParallelBuildUsers(t *testing.T) {
+	const count = 1000
+	builders := make([]*UserBuilder, count)
+	for i := range builders {
+		builders[i] = NewUserBuilder().
+			WithID(i).
+			WithName(fmt.Sprintf("User %d", i)).
+			WithEmail(fmt.Sprintf("user%d@example.com", i))
+	}
+
+	users, err := ParallelBuildUsers(builders, 8)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(users) != count {
+		t.Fatalf("Expected %d users, got %d", count, len(users))
+	}
+	for i, user := range users {
+		if user == nil || user.ID != i {
+			t.Fatalf("Expected user %d at index %d, got %+v", i, i, user)
+		}
+	}
+}
+
+func TestParallelBuildUsers_Errors(t *testing.T) {
+	builders := []*UserBuilder{
+		NewUserBuilder().WithName("Valid").WithEmail("valid@example.com"),
+		NewUserBuilder().WithName("Missing email"),
+		nil,
+		NewUserBuilder().WithID(-1),
+	}
+
+	users, err := ParallelBuildUsers(builders, 2)
+	if err == nil {
+		t.Fatal("Expected aggregated error")
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 3 {
+		t.Errorf("Expected 3 joined errors, got %v", err)
+	}
+	if users[0] == nil || users[1] != nil || users[2] != nil || users[3] != nil {
+		t.Error("Expected only the valid builder to produce a user")
+	}
+
+	if _, err = ParallelBuildUsers(builders, 0); err == nil {
+		t.Error("Expected error for non-positive worker count")
+	}
+}
+
+func TestSerialGate_StableOrder(t *testing.T) {
+	const builds = 20
+	gate := NewSerialGate()
+	ids := NewSequence(1, 1)
+
+	var mu sync.Mutex
+	var completed []*TestUser
+	var wg sync.WaitGroup
+	for range builds {
+		builder := NewUserBuilder().WithName("Jane").WithEmail("jane@example.com")
+		builder.WithSerialGate(gate)
+		builder.WithLazyMetadata("id", func(*TestUser) any { return ids.Next() })
+		builder.AddAfterBuildHook(func(result any) error {
+			user, _ := result.(*TestUser)
+			mu.Lock()
+			defer mu.Unlock()
+			completed = append(completed, user)
+			return nil
+		})
+		wg.Go(func() { builder.Build() })
+	}
+	wg.Wait()
+
+	for i, user := range completed {
+		if user.Metadata["id"] != i+1 {
+			t.Fatalf("Expected gated builds to draw IDs in completion order, got %v at position %d", user.Metadata["id"], i)
+		}
+	}
+}
+
+func TestSerialGate_CallOrder(t *testing.T) {
+	gate := NewSerialGate()
+
+	var mu sync.Mutex
+	var order []string
+	newBuilder := func(name string, latency time.Duration) *UserBuilder {
+		builder := NewUserBuilder().WithName(name).WithEmail("user@example.com")
+		builder.WithSerialGate(gate).WithSimulatedLatency(latency)
+		builder.AddAfterBuildHook(func(any) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		})
+		return builder
+	}
+	slow, fast := newBuilder("slow", 50*time.Millisecond), newBuilder("fast", 0)
+
+	var wg sync.WaitGroup
+	wg.Go(func() { slow.Build() })
+	for {
+		gate.mu.Lock()
+		entered := gate.next == 1
+		gate.mu.Unlock()
+		if entered {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	wg.Go(func() { fast.Build() })
+	wg.Wait()
+
+	if len(order) != 2 || order[0] != "slow" || order[1] != "fast" {
+		t.Errorf("Expected builds to complete in call order, got %v", order)
+	}
+}
@@ -0,0 +1,56 @@
+package testkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// LoadProfiles reads named builder configurations from a JSON object mapping profile names to configs:
+//
+//	{
+//	  "admin": {"validation_enabled": true, "tags": {"role": "admin"}, "default_values": {"age": 40}},
+//	  "trial": {"tags": {"plan": "trial"}}
+//	}
+//
+// Omitted fields keep the NewBuilderConfig defaults, and whole-number default values are decoded as int.
+func LoadProfiles(r io.Reader) (map[string]*BuilderConfig, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("cannot decode profiles: %w", err)
+	}
+
+	profiles := make(map[string]*BuilderConfig, len(raw))
+	for name, data := range raw {
+		config := NewBuilderConfig()
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("cannot decode profile '%s': %w", name, err)
+		}
+		for key, value := range config.DefaultValues {
+			config.DefaultValues[key] = normalizeJSONNumber(value)
+		}
+		profiles[name] = config
+	}
+	return profiles, nil
+}
+
+// ApplyProfile applies the named profile to a builder.
+func ApplyProfile(b Builder, profiles map[string]*BuilderConfig, name string) error {
+	config, exists := profiles[name]
+	if !exists {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+	return config.ApplyTo(b)
+}
+
+// normalizeJSONNumber converts whole-number float64 values decoded from JSON into int,
+// leaving values outside the int range as float64.
+func normalizeJSONNumber(value any) any {
+	number, ok := value.(float64)
+	// float64(math.MaxInt) rounds up to 2^63, so the upper bound is exclusive
+	if !ok || number != math.Trunc(number) || number < math.MinInt || number >= math.MaxInt {
+		return value
+	}
+	return int(number)
+}
@@ -0,0 +1,63 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"strings"
+	"testing"
+)
+
+const testProfiles = `{
+	"admin": {
+		"tags": {"role": "admin"},
+		"default_values": {"name": "Admin", "email": "admin@example.com", "age": 40, "active": true}
+	},
+	"readonly": {
+		"validation_enabled": false,
+		"tags": {"role": "readonly"}
+	}
+}`
+
+func TestLoadProfiles(t *testing.T) {
+	profiles, err := LoadProfiles(strings.NewReader(testProfiles))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("Expected 2 profiles, got %d", len(profiles))
+	}
+	if !profiles["admin"].ValidationEnabled {
+		t.Error("Expected omitted validation flag to default to true")
+	}
+	if profiles["readonly"].ValidationEnabled {
+		t.Error("Expected validation to be disabled for readonly profile")
+	}
+
+	builder := NewUserBuilder()
+	if err = ApplyProfile(builder, profiles, "admin"); err != nil {
+		t.Fatalf("Expected no error applying profile, got %v", err)
+	}
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected profiled user to build")
+	}
+	if user.Name != "Admin" || user.Age != 40 || !user.Active {
+		t.Errorf("Expected profile defaults to be applied, got %+v", user)
+	}
+	if builder.GetTag("role") != "admin" {
+		t.Error("Expected profile tags to be applied")
+	}
+}
+
+func TestLoadProfiles_Errors(t *testing.T) {
+	if _, err := LoadProfiles(strings.NewReader("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if _, err := LoadProfiles(strings.NewReader(`{"bad": {"tags": 42}}`)); err == nil {
+		t.Error("Expected error for invalid profile")
+	}
+
+	profiles, _ := LoadProfiles(strings.NewReader(testProfiles))
+	err := ApplyProfile(NewUserBuilder(), profiles, "unknown")
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("Expected clear error naming the unknown profile, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package testutil
+
+import (
+	"testing"
+
+	testkit "github.com/rios0rios0/testkit/pkg/test"
+)
+
+// IsolateDefaultFactory snapshots testkit.DefaultFactory and restores it when the test finishes,
+// so builders registered or aliased by the test don't leak into other tests.
+// Tests calling it must not run in parallel with other tests using the default factory.
+func IsolateDefaultFactory(tb testing.TB) {
+	tb.Helper()
+	factory := testkit.DefaultFactory
+	snapshot := factory.Snapshot()
+	tb.Cleanup(func() {
+		factory.Restore(snapshot)
+	})
+}
@@ -0,0 +1,27 @@
+package testutil_test
+
+import (
+	"testing"
+
+	testkit "github.com/rios0rios0/testkit/pkg/test"
+	"github.com/rios0rios0/testkit/pkg/test/testutil"
+)
+
+func TestIsolateDefaultFactory(t *testing.T) {
+	const name = "isolated-user"
+
+	t.Run("registers", func(t *testing.T) {
+		testutil.IsolateDefaultFactory(t)
+		err := testkit.RegisterBuilder(name, func() testkit.Builder { return testkit.NewUserBuilder() })
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !testkit.DefaultFactory.IsRegistered(name) {
+			t.Fatal("Expected builder to be registered during the test")
+		}
+	})
+
+	if testkit.DefaultFactory.IsRegistered(name) {
+		t.Error("Expected registration to be removed once cleanup ran")
+	}
+}
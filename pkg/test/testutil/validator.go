@@ -0,0 +1,25 @@
+// Package testutil provides helpers for testing code built on testkit, such as custom validation wiring.
+package testutil
+
+import (
+	"sync/atomic"
+
+	testkit "github.com/rios0rios0/testkit/pkg/test"
+)
+
+// CountingValidator is a validator that counts its invocations, to assert how often validation ran.
+// It always passes and is safe for concurrent use, e.g. with ValidateAsync.
+type CountingValidator struct {
+	count atomic.Int64
+}
+
+// Validate records an invocation. It matches testkit.ValidatorFunc, so it can be passed to AddValidator.
+func (v *CountingValidator) Validate(_ testkit.Builder, _ map[string]any) error {
+	v.count.Add(1)
+	return nil
+}
+
+// Count returns the number of times Validate was called.
+func (v *CountingValidator) Count() int {
+	return int(v.count.Load())
+}
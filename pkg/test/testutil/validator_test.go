@@ -0,0 +1,28 @@
+package testutil_test
+
+import (
+	"testing"
+
+	testkit "github.com/rios0rios0/testkit/pkg/test"
+	"github.com/rios0rios0/testkit/pkg/test/testutil"
+)
+
+func TestCountingValidator(t *testing.T) {
+	var validator testutil.CountingValidator
+	builder := testkit.NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com")
+	builder.AddValidator("counting", validator.Validate)
+
+	if validator.Count() != 0 {
+		t.Errorf("Expected no invocations before Build, got %d", validator.Count())
+	}
+	for range 3 {
+		if _, ok := builder.Build().(*testkit.TestUser); !ok {
+			t.Fatal("Expected user to build successfully")
+		}
+	}
+	if validator.Count() != 3 {
+		t.Errorf("Expected one invocation per Build, got %d", validator.Count())
+	}
+}
@@ -0,0 +1,236 @@
+package testkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaskedEmail returns the email with the local part partially obscured, e.g. "a***e@example.com".
+// Local parts of one or two characters keep at most their first character.
+func (u *TestUser) MaskedEmail() string {
+	local, domain, found := strings.Cut(u.Email, "@")
+	if !found {
+		return maskLocalPart(u.Email)
+	}
+	return maskLocalPart(local) + "@" + domain
+}
+
+// CanonicalEmail returns the email normalized for identity comparisons: trimmed and lowercased,
+// with any "+tag" suffix removed from the local part. For Gmail addresses, dots in the local part are
+// removed and "googlemail.com" becomes "gmail.com", since Gmail ignores them.
+func (u *TestUser) CanonicalEmail() string {
+	email := strings.ToLower(strings.TrimSpace(u.Email))
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return email
+	}
+	local, _, _ = strings.Cut(local, "+")
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+	return local + "@" + domain
+}
+
+// maskLocalPart obscures the middle of an email local part.
+func maskLocalPart(local string) string {
+	runes := []rune(local)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1:
+		return "*"
+	case 2: //nolint:mnd // two-character local parts only keep the first character
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + "***" + string(runes[len(runes)-1])
+	}
+}
+
+// IsExpired reports whether the deadline set with UserBuilder.WithDeadline has been reached according to the clock.
+// Users without a deadline never expire. A nil clock uses the global clock, see SetGlobalClock.
+func (u *TestUser) IsExpired(clock Clock) bool {
+	deadline, ok := u.Metadata[DeadlineMetadataKey].(time.Time)
+	if !ok {
+		return false
+	}
+	clock = clockOrGlobal(clock)
+	return !clock.Now().Before(deadline)
+}
+
+// CompositeKey returns the key computed by UserBuilder.WithCompositeKey, or an empty string if none was stored.
+func (u *TestUser) CompositeKey() string {
+	key, _ := u.Metadata[CompositeKeyMetadataKey].(string)
+	return key
+}
+
+// fieldString returns the string form of a field ("id", "name", "email", "age", "active")
+// or of a metadata value addressed as "metadata.<key>". It reports false for unknown fields.
+func (u *TestUser) fieldString(field string) (string, bool) {
+	if key, found := strings.CutPrefix(field, "metadata."); found {
+		value, exists := u.Metadata[key]
+		if !exists {
+			return "", true
+		}
+		return fmt.Sprint(value), true
+	}
+	switch field {
+	case "id":
+		return strconv.Itoa(u.ID), true
+	case "name":
+		return u.Name, true
+	case "email":
+		return u.Email, true
+	case "age":
+		return strconv.Itoa(u.Age), true
+	case "active":
+		return strconv.FormatBool(u.Active), true
+	default:
+		return "", false
+	}
+}
+
+// FieldMapper renames JSON output keys, mapping TestUser field names (e.g. "Name") to API names (e.g. "full_name").
+type FieldMapper map[string]string
+
+// MarshalJSONWith marshals the user to JSON, renaming the keys listed in mapper.
+// Unmapped fields keep their default names. Mapping two fields to the same key is an error.
+func (u *TestUser) MarshalJSONWith(mapper FieldMapper) ([]byte, error) {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	renamed := make(map[string]json.RawMessage, len(fields))
+	for name, value := range fields {
+		key := name
+		if mapped, exists := mapper[name]; exists {
+			key = mapped
+		}
+		if _, duplicate := renamed[key]; duplicate {
+			return nil, fmt.Errorf("field mapper produces duplicate key '%s'", key)
+		}
+		renamed[key] = value
+	}
+	return json.Marshal(renamed)
+}
+
+// TableRow returns the values of the requested columns as strings, for tabular test logs.
+// Columns are the names accepted by WithCompositeKey, including "metadata.<key>"; unknown columns are empty.
+func (u *TestUser) TableRow(columns ...string) []string {
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i], _ = u.fieldString(column)
+	}
+	return row
+}
+
+// UsersTable renders users as an aligned ASCII table with a header of the column names,
+// e.g. t.Log(UsersTable(users, "id", "name", "email")).
+func UsersTable(users []*TestUser, columns ...string) string {
+	rows := make([][]string, 0, len(users)+1)
+	rows = append(rows, columns)
+	for _, user := range users {
+		rows = append(rows, user.TableRow(columns...))
+	}
+
+	widths := make([]int, len(columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var sb strings.Builder
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(cells, " | "), " "))
+		sb.WriteString("\n")
+		if r == 0 {
+			separators := make([]string, len(widths))
+			for i, width := range widths {
+				separators[i] = strings.Repeat("-", width)
+			}
+			sb.WriteString(strings.Join(separators, "-+-"))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// MetadataAs returns the metadata value stored under key asserted to T.
+// It returns the zero value and false when the key is absent or holds a value of another type.
+func MetadataAs[T any](u *TestUser, key string) (T, bool) {
+	value, ok := u.Metadata[key].(T)
+	return value, ok
+}
+
+// MetadataInNamespace returns the metadata stored under the "ns." prefix, with the prefix stripped.
+func (u *TestUser) MetadataInNamespace(ns string) map[string]any {
+	prefix := ns + "."
+	result := make(map[string]any)
+	for key, value := range u.Metadata {
+		if stripped, found := strings.CutPrefix(key, prefix); found {
+			result[stripped] = value
+		}
+	}
+	return result
+}
+
+// UsersEqualIgnoring compares two users field by field, skipping the named fields.
+// Field names are case-insensitive ("id", "name", "email", "age", "active", "schemaversion", "tags", "metadata");
+// individual tag and metadata keys are ignored with dotted paths such as "metadata.created_at".
+func UsersEqualIgnoring(a, b *TestUser, ignoreFields ...string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	left, right := copyUser(a), copyUser(b)
+	for _, field := range ignoreFields {
+		clearUserField(left, field)
+		clearUserField(right, field)
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// clearUserField resets a field, tag, or metadata key so it is excluded from comparisons.
+func clearUserField(user *TestUser, field string) {
+	name, key, nested := strings.Cut(field, ".")
+	switch strings.ToLower(name) {
+	case "id":
+		user.ID = 0
+	case "name":
+		user.Name = ""
+	case "email":
+		user.Email = ""
+	case "age":
+		user.Age = 0
+	case "active":
+		user.Active = false
+	case "schemaversion":
+		user.SchemaVersion = 0
+	case "tags":
+		if nested {
+			delete(user.Tags, key)
+		} else {
+			clear(user.Tags)
+		}
+	case "metadata":
+		if nested {
+			delete(user.Metadata, key)
+		} else {
+			clear(user.Metadata)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTestUser_MaskedEmail(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{email: "alice@example.com", expected: "a***e@example.com"},
+		{email: "bob@example.com", expected: "b***b@example.com"},
+		{email: "ab@example.com", expected: "a*@example.com"},
+		{email: "a@example.com", expected: "*@example.com"},
+		{email: "@example.com", expected: "@example.com"},
+		{email: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		user := &TestUser{Email: tt.email}
+		if got := user.MaskedEmail(); got != tt.expected {
+			t.Errorf("MaskedEmail(%q): expected %q, got %q", tt.email, tt.expected, got)
+		}
+	}
+}
+
+func TestUserBuilder_WithStoreMaskedEmail(t *testing.T) {
+	user, ok := NewUserBuilder().
+		WithName("Alice").
+		WithEmail("alice@example.com").
+		WithStoreMaskedEmail("masked_email").
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user to build successfully")
+	}
+	if user.Metadata["masked_email"] != "a***e@example.com" {
+		t.Errorf("Expected masked email in metadata, got %v", user.Metadata["masked_email"])
+	}
+}
+
+func TestUsersEqualIgnoring(t *testing.T) {
+	a := &TestUser{
+		ID:       1,
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Tags:     map[string]string{"role": "admin"},
+		Metadata: map[string]any{"created_at": "2026-01-01T00:00:00Z", "source": "test"},
+	}
+	b := &TestUser{
+		ID:       2,
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Tags:     map[string]string{"role": "admin"},
+		Metadata: map[string]any{"created_at": "2026-02-02T00:00:00Z", "source": "test"},
+	}
+
+	if UsersEqualIgnoring(a, b) {
+		t.Error("Expected users to differ without ignored fields")
+	}
+	if UsersEqualIgnoring(a, b, "ID") {
+		t.Error("Expected users to differ when only ID is ignored")
+	}
+	if !UsersEqualIgnoring(a, b, "ID", "metadata.created_at") {
+		t.Error("Expected users to be equal ignoring ID and metadata.created_at")
+	}
+
+	b.Metadata["source"] = "other"
+	if UsersEqualIgnoring(a, b, "id", "metadata.created_at") {
+		t.Error("Expected other metadata keys to still be compared")
+	}
+	if !UsersEqualIgnoring(a, b, "id", "metadata") {
+		t.Error("Expected all metadata to be ignored")
+	}
+
+	// Ignoring fields must not modify the inputs
+	if a.ID != 1 || a.Metadata["created_at"] == nil {
+		t.Error("Expected inputs to be left untouched")
+	}
+
+	if !UsersEqualIgnoring(nil, nil) || UsersEqualIgnoring(a, nil) {
+		t.Error("Expected nil users to be equal only to nil")
+	}
+}
+
+func TestTestUser_MetadataInNamespace(t *testing.T) {
+	builder := NewUserBuilder().
+		WithName("John Doe").
+		WithEmail("john@example.com").
+		WithMetadata("source", "default").
+		WithMetadataNamespace("billing").
+		WithMetadata("plan", "pro").
+		WithMetadata("seats", 5).
+		WithGroup("A").
+		WithMetadataNamespace("").
+		WithMetadata("visits", 1)
+
+	user, ok := builder.Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user to build")
+	}
+
+	if user.Metadata["billing.plan"] != "pro" || user.Metadata["billing.seats"] != 5 {
+		t.Errorf("Expected namespaced keys, got %v", user.Metadata)
+	}
+	if user.Metadata["source"] != "default" || user.Metadata["visits"] != 1 {
+		t.Errorf("Expected unprefixed keys outside the namespace, got %v", user.Metadata)
+	}
+	if user.Metadata[GroupMetadataKey] != "A" {
+		t.Error("Expected group key not to be namespaced")
+	}
+
+	billing := user.MetadataInNamespace("billing")
+	if len(billing) != 2 || billing["plan"] != "pro" || billing["seats"] != 5 {
+		t.Errorf("Expected stripped billing namespace, got %v", billing)
+	}
+	if len(user.MetadataInNamespace("missing")) != 0 {
+		t.Error("Expected empty map for unknown namespace")
+	}
+}
+
+func TestTestUser_MarshalJSONWith(t *testing.T) {
+	user := &TestUser{ID: 7, Name: "Jane Doe", Email: "jane@example.com"}
+
+	data, err := user.MarshalJSONWith(FieldMapper{"Name": "full_name", "Email": "email_address"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if fields["full_name"] != "Jane Doe" || fields["email_address"] != "jane@example.com" {
+		t.Errorf("Expected renamed keys, got %v", fields)
+	}
+	if _, exists := fields["Name"]; exists {
+		t.Error("Expected the original Name key to be replaced")
+	}
+	if fields["ID"] != float64(7) {
+		t.Errorf("Expected unmapped ID key to be kept, got %v", fields)
+	}
+
+	if _, err = user.MarshalJSONWith(FieldMapper{"Name": "Email"}); err == nil {
+		t.Error("Expected error for a mapper producing duplicate keys")
+	}
+}
+
+func TestTestUser_TableRow(t *testing.T) {
+	user := &TestUser{ID: 1, Name: "Jane", Email: "jane@example.com", Metadata: map[string]any{"team": "core"}}
+
+	row := user.TableRow("name", "metadata.team", "id", "unknown")
+	expected := []string{"Jane", "core", "1", ""}
+	for i := range expected {
+		if row[i] != expected[i] {
+			t.Errorf("Column %d: expected %q, got %q", i, expected[i], row[i])
+		}
+	}
+}
+
+func TestUsersTable(t *testing.T) {
+	users := []*TestUser{
+		{ID: 1, Name: "Jane", Email: "jane@example.com"},
+		{ID: 20, Name: "Zoë Ångström", Email: "zoe@example.com"},
+	}
+
+	expected := "id | name         | email\n" +
+		"---+--------------+-----------------\n" +
+		"1  | Jane         | jane@example.com\n" +
+		"20 | Zoë Ångström | zoe@example.com\n"
+	if table := UsersTable(users, "id", "name", "email"); table != expected {
+		t.Errorf("Expected table:\n%s\ngot:\n%s", expected, table)
+	}
+}
+
+func TestTestUser_CanonicalEmail(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{email: "Alice@Example.COM", expected: "alice@example.com"},
+		{email: " alice@example.com ", expected: "alice@example.com"},
+		{email: "alice+newsletter@example.com", expected: "alice@example.com"},
+		{email: "first.last@example.com", expected: "first.last@example.com"},
+		{email: "First.Last+spam@Gmail.com", expected: "firstlast@gmail.com"},
+		{email: "f.i.r.s.t@googlemail.com", expected: "first@gmail.com"},
+		{email: "not-an-email", expected: "not-an-email"},
+	}
+
+	for _, tt := range tests {
+		user := &TestUser{Email: tt.email}
+		if got := user.CanonicalEmail(); got != tt.expected {
+			t.Errorf("CanonicalEmail(%q): expected %q, got %q", tt.email, tt.expected, got)
+		}
+	}
+}
+
+func TestUserBuilder_WithStoreCanonicalEmail(t *testing.T) {
+	user, ok := NewUserBuilder().
+		WithName("Alice").
+		WithEmail("A.lice+test@gmail.com").
+		WithStoreCanonicalEmail("canonical_email").
+		Build().(*TestUser)
+	if !ok {
+		t.Fatal("Expected user to build successfully")
+	}
+	if user.Metadata["canonical_email"] != "alice@gmail.com" {
+		t.Errorf("Expected canonical email in metadata, got %v", user.Metadata["canonical_email"])
+	}
+}
+
+func TestMetadataAs(t *testing.T) {
+	type address struct {
+		City string
+	}
+	user := &TestUser{Metadata: map[string]any{
+		"address": address{City: "Lisbon"},
+		"roles":   []string{"admin", "writer"},
+		"count":   3,
+	}}
+
+	if got, ok := MetadataAs[address](user, "address"); !ok || got.City != "Lisbon" {
+		t.Errorf("Expected custom struct, got %v (%v)", got, ok)
+	}
+	if got, ok := MetadataAs[[]string](user, "roles"); !ok || len(got) != 2 || got[0] != "admin" {
+		t.Errorf("Expected slice, got %v (%v)", got, ok)
+	}
+	if got, ok := MetadataAs[string](user, "count"); ok || got != "" {
+		t.Errorf("Expected zero value and false on type mismatch, got %q (%v)", got, ok)
+	}
+	if got, ok := MetadataAs[int](user, "missing"); ok || got != 0 {
+		t.Errorf("Expected zero value and false for a missing key, got %d (%v)", got, ok)
+	}
+}
@@ -0,0 +1,259 @@
+package testkit
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// validationTagName is the struct tag key read by Validate.
+const validationTagName = "testkit"
+
+// defaultRule is the `testkit` tag entry declaring a field default, e.g. "default=unknown".
+const defaultRule = "default"
+
+//nolint:gochecknoglobals // registry shared by all builders, like DefaultFactory
+var (
+	validationProfilesMu sync.RWMutex
+	validationProfiles   = make(map[string][]func(Builder) error)
+)
+
+// RegisterValidationProfile registers a named set of validation rules, selectable with WithValidationProfile.
+// Registering an existing name replaces its rules.
+func RegisterValidationProfile(name string, rules []func(Builder) error) error {
+	if name == "" {
+		return errors.New("validation profile name cannot be empty")
+	}
+	validationProfilesMu.Lock()
+	defer validationProfilesMu.Unlock()
+	validationProfiles[name] = slices.Clone(rules)
+	return nil
+}
+
+// validationProfile returns the rules of a registered validation profile.
+func validationProfile(name string) ([]func(Builder) error, bool) {
+	validationProfilesMu.RLock()
+	defer validationProfilesMu.RUnlock()
+	rules, exists := validationProfiles[name]
+	return rules, exists
+}
+
+// Validatable is implemented by values that can check themselves, such as rich metadata objects.
+type Validatable interface {
+	Validate() error
+}
+
+// Report is a structured summary of a builder's validation state, suitable for CI dashboards.
+type Report struct {
+	// Valid is true when there are no field errors
+	Valid bool
+	// FieldErrors lists every validation failure; errors not tied to a field have an empty Field
+	FieldErrors []*FieldError
+	// Warnings lists the non-fatal issues recorded by the builder
+	Warnings []error
+	// Tags holds the builder's tags
+	Tags map[string]string
+}
+
+// Validate checks the fields of a struct (or pointer to struct) against their `testkit` tags.
+// Supported rules are "required", "min=N", "max=N" and "email", separated by commas.
+// "default=V" entries declare defaults for ApplyStructDefaults and are ignored here.
+// For strings, min and max apply to the length; for numbers, to the value itself.
+// All violations are aggregated into a single error.
+func Validate(v any) error {
+	return validateStruct(v, nil)
+}
+
+// validateStruct implements Validate, skipping the fields named in skip.
+func validateStruct(v any, skip map[string]bool) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return errors.New("cannot validate a nil pointer")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot validate non-struct type %T", v)
+	}
+
+	var errs []error
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		tag, ok := field.Tag.Lookup(validationTagName)
+		if !ok || !field.IsExported() || skip[field.Name] {
+			continue
+		}
+		for rule := range strings.SplitSeq(tag, ",") {
+			if err := checkRule(field.Name, rule, value.Field(i)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkRule applies a single validation rule to a field value.
+func checkRule(name, rule string, field reflect.Value) error {
+	ruleName, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+	switch ruleName {
+	case "", defaultRule:
+		return nil
+	case "required":
+		if field.IsZero() {
+			return fmt.Errorf("field %s is required", name)
+		}
+	case "min", "max":
+		return checkBound(name, ruleName, arg, field)
+	case "email":
+		if field.Kind() != reflect.String {
+			return fmt.Errorf("field %s: rule 'email' requires a string", name)
+		}
+		if email := field.String(); email != "" && !isValidEmail(email) {
+			return fmt.Errorf("field %s must be a valid email address", name)
+		}
+	default:
+		return fmt.Errorf("field %s: unknown validation rule '%s'", name, ruleName)
+	}
+	return nil
+}
+
+// checkBound applies a min or max rule to a numeric field or to a string length.
+func checkBound(name, ruleName, arg string, field reflect.Value) error {
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("field %s: invalid '%s' argument '%s'", name, ruleName, arg)
+	}
+
+	var actual float64
+	switch field.Kind() { //nolint:exhaustive // only numeric and string kinds are supported
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		actual = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		actual = field.Float()
+	case reflect.String:
+		actual = float64(len([]rune(field.String())))
+	default:
+		return fmt.Errorf("field %s: rule '%s' is not supported for kind %s", name, ruleName, field.Kind())
+	}
+
+	if ruleName == "min" && actual < bound {
+		return fmt.Errorf("field %s must be at least %s", name, arg)
+	}
+	if ruleName == "max" && actual > bound {
+		return fmt.Errorf("field %s must be at most %s", name, arg)
+	}
+	return nil
+}
+
+// isValidEmail performs a lightweight structural check of an email address.
+func isValidEmail(email string) bool {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" || strings.Contains(domain, "@") {
+		return false
+	}
+	dot := strings.LastIndex(domain, ".")
+	return dot > 0 && dot < len(domain)-1
+}
+
+// DiffValidation compares two validation snapshots, returning the messages only in after (added)
+// and only in before (removed). Repeated messages are compared by count. Both results are sorted.
+func DiffValidation(before, after []string) (added, removed []string) {
+	counts := make(map[string]int, len(before))
+	for _, message := range before {
+		counts[message]++
+	}
+
+	for _, message := range after {
+		if counts[message] > 0 {
+			counts[message]--
+			continue
+		}
+		added = append(added, message)
+	}
+
+	for message, count := range counts {
+		for range count {
+			removed = append(removed, message)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+// applyStructDefaults sets the fields of the struct pointed to by v to their `testkit:"default=V"` values,
+// parsing V to the field's type. Fields for which isSet returns true are left untouched.
+// It returns the names of the fields that were set. Defaults cannot contain commas.
+func applyStructDefaults(v any, isSet func(field string) bool) ([]string, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot apply defaults to %T, expected a non-nil struct pointer", v)
+	}
+	value = value.Elem()
+
+	var applied []string
+	var errs []error
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		tag, ok := field.Tag.Lookup(validationTagName)
+		if !ok || !field.IsExported() || isSet(field.Name) {
+			continue
+		}
+		for rule := range strings.SplitSeq(tag, ",") {
+			ruleName, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+			if ruleName != defaultRule {
+				continue
+			}
+			if err := setFromString(value.Field(i), arg); err != nil {
+				errs = append(errs, fmt.Errorf("field %s: invalid default '%s': %w", field.Name, arg, err))
+				continue
+			}
+			applied = append(applied, field.Name)
+		}
+	}
+	return applied, errors.Join(errs...)
+}
+
+// setFromString parses raw to the kind of field and stores it.
+func setFromString(field reflect.Value, raw string) error {
+	switch field.Kind() { //nolint:exhaustive // only scalar kinds are supported
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+	return nil
+}
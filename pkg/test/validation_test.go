@@ -0,0 +1,181 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type validatedEntity struct {
+	Name  string `testkit:"required"`
+	Email string `testkit:"email"`
+	Score int    `testkit:"min=0,max=100"`
+	Code  string `testkit:"min=2,max=4"`
+}
+
+func TestValidate_Required(t *testing.T) {
+	if err := Validate(validatedEntity{Name: "valid", Code: "ab"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if err := Validate(validatedEntity{Code: "ab"}); err == nil {
+		t.Error("Expected error for missing required field")
+	}
+}
+
+func TestValidate_Min(t *testing.T) {
+	if err := Validate(validatedEntity{Name: "valid", Score: -1, Code: "ab"}); err == nil {
+		t.Error("Expected error for value below min")
+	}
+
+	if err := Validate(validatedEntity{Name: "valid", Code: "a"}); err == nil {
+		t.Error("Expected error for string shorter than min")
+	}
+}
+
+func TestValidate_Max(t *testing.T) {
+	if err := Validate(validatedEntity{Name: "valid", Score: 101, Code: "ab"}); err == nil {
+		t.Error("Expected error for value above max")
+	}
+
+	if err := Validate(validatedEntity{Name: "valid", Code: "abcde"}); err == nil {
+		t.Error("Expected error for string longer than max")
+	}
+}
+
+func TestValidate_Email(t *testing.T) {
+	valid := []string{"", "john@example.com", "a.b+c@sub.example.org"}
+	for _, email := range valid {
+		if err := Validate(validatedEntity{Name: "valid", Email: email, Code: "ab"}); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", email, err)
+		}
+	}
+
+	invalid := []string{"john", "@example.com", "john@example", "john@@example.com", "john@example."}
+	for _, email := range invalid {
+		if err := Validate(validatedEntity{Name: "valid", Email: email, Code: "ab"}); err == nil {
+			t.Errorf("Expected %q to be invalid", email)
+		}
+	}
+}
+
+func TestValidate_AggregatesErrors(t *testing.T) {
+	err := Validate(&validatedEntity{Email: "bad", Score: 200})
+	if err == nil {
+		t.Fatal("Expected aggregated error")
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined error, got %T", err)
+	}
+	if len(joined.Unwrap()) != 4 {
+		t.Errorf("Expected 4 errors, got %d: %v", len(joined.Unwrap()), err)
+	}
+}
+
+func TestValidate_InvalidInput(t *testing.T) {
+	if err := Validate(nil); err == nil {
+		t.Error("Expected error for nil input")
+	}
+
+	var nilEntity *validatedEntity
+	if err := Validate(nilEntity); err == nil {
+		t.Error("Expected error for nil pointer")
+	}
+
+	if err := Validate(42); err == nil {
+		t.Error("Expected error for non-struct input")
+	}
+
+	type unknownRule struct {
+		Field string `testkit:"bogus"`
+	}
+	if err := Validate(unknownRule{}); err == nil {
+		t.Error("Expected error for unknown rule")
+	}
+}
+
+func TestUserBuilder_WithStructValidation(t *testing.T) {
+	builder := NewUserBuilder()
+	builder.WithName("John Doe").WithEmail("not-an-email")
+
+	// Struct validation is disabled by default
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected build to succeed without struct validation")
+	}
+
+	result := builder.WithStructValidation(true).Build()
+	if _, isError := result.(error); !isError {
+		t.Error("Expected struct validation to reject invalid email")
+	}
+
+	builder.WithEmail("john@example.com")
+	if _, ok := builder.Build().(*TestUser); !ok {
+		t.Error("Expected build to succeed with a valid email")
+	}
+}
+
+func TestDiffValidation(t *testing.T) {
+	builder := NewUserBuilder().WithAge(-1)
+	before := builder.ValidationSnapshot()
+	if len(before) != 1 {
+		t.Fatalf("Expected 1 message in the snapshot, got %v", before)
+	}
+
+	builder.AddError(errors.New("b: second problem"))
+	builder.AddError(errors.New("a: first problem"))
+	after := builder.ValidationSnapshot()
+	if !slices.IsSorted(after) {
+		t.Errorf("Expected snapshot to be sorted, got %v", after)
+	}
+
+	added, removed := DiffValidation(before, after)
+	if !slices.Equal(added, []string{"a: first problem", "b: second problem"}) {
+		t.Errorf("Expected added messages, got %v", added)
+	}
+	if len(removed) != 0 {
+		t.Errorf("Expected no removed messages, got %v", removed)
+	}
+
+	builder.ClearErrors()
+	added, removed = DiffValidation(after, builder.ValidationSnapshot())
+	if len(added) != 0 || len(removed) != 3 {
+		t.Errorf("Expected all messages removed, got added %v and removed %v", added, removed)
+	}
+}
+
+func TestApplyStructDefaults(t *testing.T) {
+	type defaulted struct {
+		Name    string  `testkit:"required,default=unknown"`
+		Count   int     `testkit:"default=3,min=0"`
+		Ratio   float64 `testkit:"default=0.5"`
+		Enabled bool    `testkit:"default=true"`
+		Plain   string
+	}
+
+	entity := defaulted{Name: "kept"}
+	applied, err := applyStructDefaults(&entity, func(field string) bool { return field == "Name" })
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entity.Name != "kept" || entity.Count != 3 || entity.Ratio != 0.5 || !entity.Enabled || entity.Plain != "" {
+		t.Errorf("Unexpected defaults applied: %+v", entity)
+	}
+	if !slices.Equal(applied, []string{"Count", "Ratio", "Enabled"}) {
+		t.Errorf("Expected the applied fields to be reported, got %v", applied)
+	}
+	if err = Validate(entity); err != nil {
+		t.Errorf("Expected default entries to be ignored by Validate, got %v", err)
+	}
+
+	type invalid struct {
+		Count int `testkit:"default=many"`
+	}
+	if _, err = applyStructDefaults(&invalid{}, func(string) bool { return false }); err == nil {
+		t.Error("Expected an error for an unparsable default")
+	}
+	if _, err = applyStructDefaults(defaulted{}, func(string) bool { return false }); err == nil {
+		t.Error("Expected an error for a non-pointer")
+	}
+}
@@ -0,0 +1,43 @@
+package testkit
+
+// BuilderView is a read-only accessor over a builder.
+// APIs can accept a BuilderView to signal that they won't mutate the builder;
+// it reflects the live state of the underlying builder.
+type BuilderView struct {
+	base    *BaseBuilder
+	preview func() any
+}
+
+// ReadOnly returns a read-only view of the builder.
+func (b *BaseBuilder) ReadOnly() BuilderView {
+	return BuilderView{base: b}
+}
+
+// GetTag retrieves a metadata tag value by key.
+func (v BuilderView) GetTag(key string) string {
+	return v.base.GetTag(key)
+}
+
+// HasTag checks if a metadata tag exists.
+func (v BuilderView) HasTag(key string) bool {
+	return v.base.HasTag(key)
+}
+
+// IsValidationEnabled returns whether validation is enabled for the builder.
+func (v BuilderView) IsValidationEnabled() bool {
+	return v.base.IsValidationEnabled()
+}
+
+// HasErrors returns true if the builder has any errors.
+func (v BuilderView) HasErrors() bool {
+	return v.base.HasErrors()
+}
+
+// Preview returns a copy of the entity currently being built, without validating or building it.
+// Returns nil for builders that don't expose their entity.
+func (v BuilderView) Preview() any {
+	if v.preview == nil {
+		return nil
+	}
+	return v.preview()
+}
@@ -0,0 +1,55 @@
+package testkit //nolint:testpackage // tests require access to unexported fields for thorough verification
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuilderView(t *testing.T) {
+	builder := NewUserBuilder()
+	view := builder.ReadOnly()
+
+	if view.HasTag("env") || view.HasErrors() || !view.IsValidationEnabled() {
+		t.Error("Expected view to reflect the initial builder state")
+	}
+
+	// The view reflects live state
+	builder.WithTag("env", "test").WithValidation(false)
+	builder.WithName("John Doe")
+	builder.AddError(ErrNegativeID)
+
+	if view.GetTag("env") != "test" || !view.HasTag("env") {
+		t.Error("Expected view to reflect tags added after creation")
+	}
+	if view.IsValidationEnabled() {
+		t.Error("Expected view to reflect validation changes")
+	}
+	if !view.HasErrors() {
+		t.Error("Expected view to reflect errors")
+	}
+
+	preview, ok := view.Preview().(*TestUser)
+	if !ok || preview.Name != "John Doe" {
+		t.Fatalf("Expected preview of the in-progress user, got %v", view.Preview())
+	}
+	preview.Name = "Modified"
+	if builder.user.Name != "John Doe" {
+		t.Error("Modifying the preview should not affect the builder")
+	}
+
+	if NewBaseBuilder().ReadOnly().Preview() != nil {
+		t.Error("Expected nil preview for BaseBuilder")
+	}
+}
+
+func TestBuilderView_NoMutators(t *testing.T) {
+	viewType := reflect.TypeFor[BuilderView]()
+	for i := range viewType.NumMethod() {
+		name := viewType.Method(i).Name
+		if strings.HasPrefix(name, "With") || strings.HasPrefix(name, "Add") ||
+			strings.HasPrefix(name, "Set") || name == "Reset" || name == "ClearErrors" {
+			t.Errorf("BuilderView should not expose mutator %s", name)
+		}
+	}
+}